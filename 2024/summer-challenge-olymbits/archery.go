@@ -1,6 +1,10 @@
 package main
 
-import "math"
+import (
+	"math"
+
+	"github.com/mendel/codingames/olymbits/rules"
+)
 
 type Archery struct {
 	Race
@@ -20,7 +24,7 @@ func NewArchery(archers ...*Archer) *Archery {
 }
 
 func (a Archery) wind() int {
-	return toInt(string(a.gpu[0]))
+	return int(a.gpu[0] - '0')
 }
 
 func (a Archery) Place(p Player) int {
@@ -73,6 +77,19 @@ func (a Archery) Eval(cmd Command, playerIdx int) int {
 	return int(100 * normalize(float64(score), -9, 9+float64(len(a.gpu)-1)))
 }
 
+func (a Archery) Step(cmds [3]Command) (string, [7]int) {
+	return rules.Archery(a.gpu, a.regs, cmds)
+}
+
+// remaining is the number of winds left.
+func (a Archery) remaining() int {
+	if a.isEOG() {
+		return 0
+	}
+
+	return len(a.gpu)
+}
+
 var Origin Coord = Coord{0, 0}
 
 type Coord struct {
@@ -94,3 +111,7 @@ func (a Archer) coord() Coord {
 		y: *a.regs[1],
 	}
 }
+
+func (a Archery) standing() rules.Standing {
+	return rules.ArcheryStanding
+}
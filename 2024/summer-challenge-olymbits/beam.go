@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// Planner is a decision procedure the engine can use instead of Exec.
+type Planner interface {
+	Plan(e Engine) Command
+}
+
+// Snapshot holds the registers of one mini-game at a given turn.
+type Snapshot struct {
+	gpu  string
+	regs [7]int
+}
+
+type beamNode struct {
+	states [4]Snapshot
+	evals  [4]int
+	line   []Command
+	value  float64
+}
+
+// BeamSearch plans a line of our commands over all four mini-games at once.
+// Every depth advances each game with our command and a predicted command
+// for the opponents, and only the best width nodes are kept.
+type BeamSearch struct {
+	depth int
+	width int
+
+	// next guesses the registers of the run following a reset turn
+	next [4]Snapshot
+
+	// scratch games are loaded with a node's registers to step and rate it
+	scratch [4]Game
+}
+
+// NewBeamSearch returns a beam search planning depth turns ahead and keeping
+// width nodes per depth, both at least 1.
+func NewBeamSearch(depth, width int) (*BeamSearch, error) {
+	if depth < 1 || width < 1 {
+		return nil, fmt.Errorf("beam depth and width must be at least 1, got %d and %d", depth, width)
+	}
+
+	return &BeamSearch{
+		depth: depth,
+		width: width,
+		scratch: [4]Game{
+			NewHurdling(NewHurdler(), NewHurdler(), NewHurdler()),
+			NewArchery(NewArcher(), NewArcher(), NewArcher()),
+			NewSkating(NewSkater(), NewSkater(), NewSkater()),
+			NewDiving(NewDiver(), NewDiver(), NewDiver()),
+		},
+	}, nil
+}
+
+func (b *BeamSearch) Plan(e Engine) Command {
+	root := beamNode{}
+	for g, key := range Order {
+		root.states[g] = e.races[key].snapshot()
+		b.next[g], _ = NextRun(e.races[key])
+	}
+
+	beam := []beamNode{root}
+	for depth := 1; depth <= b.depth; depth++ {
+		next := make([]beamNode, 0, len(beam)*len(Commands))
+
+		for _, node := range beam {
+			// the opponents don't depend on our command, they are
+			// predicted once per node
+			predicted := b.predict(node, e.playerIdx)
+			for _, cmd := range Commands {
+				next = append(next, b.advance(e, node, predicted, cmd, depth))
+			}
+		}
+
+		sort.SliceStable(next, func(i, j int) bool {
+			return next[i].value > next[j].value
+		})
+
+		if len(next) > b.width {
+			next = next[:b.width]
+		}
+		beam = next
+	}
+
+	best := beam[0]
+	fmt.Fprintf(os.Stderr, "BEAM: %s, VALUE: %.3f\n", formatLine(best.line), best.value)
+
+	return best.line[0]
+}
+
+// advance plays cmd for us and the predicted commands for the opponents
+// from node, and rates the resulting node.
+func (b *BeamSearch) advance(e Engine, node beamNode, predicted [3]Command, cmd Command, depth int) beamNode {
+	child := beamNode{
+		evals: node.evals,
+		line:  append(node.line[:len(node.line):len(node.line)], cmd),
+		value: 1,
+	}
+
+	cmds := predicted
+	cmds[e.playerIdx] = cmd
+
+	for g, game := range b.scratch {
+		game.load(node.states[g])
+
+		// a game on its reset turn restarts on the next one, plan its new
+		// run as a replay of the last one
+		if game.isEOG() && b.next[g].gpu != "" {
+			child.states[g] = b.next[g]
+		} else {
+			child.evals[g] += game.Eval(cmd, e.playerIdx)
+			gpu, regs := game.Step(cmds)
+			child.states[g] = Snapshot{gpu, regs}
+		}
+
+		// Eval ranges from -100 to 100, mapping it to [0, 1] lets it stand
+		// for the chance of improving the mini-game score
+		gain := float64(child.evals[g]+100*depth) / float64(200*depth)
+		score := e.races[Order[g]].Player(e.playerIdx).Score()
+		child.value *= float64(score) + gain
+	}
+
+	return child
+}
+
+// predict guesses every opponent's command as the one maximizing its total
+// Eval over the mini-games loaded into scratch from node.
+func (b *BeamSearch) predict(node beamNode, playerIdx int) [3]Command {
+	cmds := [3]Command{}
+
+	for i := range cmds {
+		if i == playerIdx {
+			continue
+		}
+
+		best := -1 << 31
+		for _, cmd := range Commands {
+			total := 0
+			for g, game := range b.scratch {
+				game.load(node.states[g])
+				total += game.Eval(cmd, i)
+			}
+
+			if total > best {
+				best = total
+				cmds[i] = cmd
+			}
+		}
+	}
+
+	return cmds
+}
+
+func formatLine(line []Command) string {
+	var sb strings.Builder
+	for i, cmd := range line {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(string(cmd))
+	}
+	return sb.String()
+}
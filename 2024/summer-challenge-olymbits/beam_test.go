@@ -0,0 +1,67 @@
+package main
+
+import "testing"
+
+func TestNewBeamSearchRejectsEmptyBeams(t *testing.T) {
+	for _, size := range [][2]int{{0, 16}, {4, 0}, {-1, -1}} {
+		if _, err := NewBeamSearch(size[0], size[1]); err == nil {
+			t.Errorf("depth %d, width %d accepted", size[0], size[1])
+		}
+	}
+}
+
+func TestBeamSearchPlan(t *testing.T) {
+	reset := [7]int{0, 0, 0, 0, 0, 0, -1}
+
+	tests := []struct {
+		name    string
+		hurdles string
+		goal    string
+		want    Command
+	}{
+		{
+			// every other command lands on or runs into the hurdle
+			name:    "hurdle two spaces ahead",
+			hurdles: "..#...........",
+			goal:    EOG,
+			want:    LEFT,
+		},
+		{
+			name:    "diving goal",
+			hurdles: EOG,
+			goal:    "DDDDD",
+			want:    DOWN,
+		},
+		{
+			name:    "both agree",
+			hurdles: "..#...........",
+			goal:    "LLLL",
+			want:    LEFT,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := NewEngine(
+				0,
+				NewHurdling(NewHurdler(), NewHurdler(), NewHurdler()),
+				NewArchery(NewArcher(), NewArcher(), NewArcher()),
+				NewSkating(NewSkater(), NewSkater(), NewSkater()),
+				NewDiving(NewDiver(), NewDiver(), NewDiver()),
+			)
+			UpdateGame(engine.races[HURDLING], tt.hurdles, reset)
+			UpdateGame(engine.races[ARCHERY], EOG, reset)
+			UpdateGame(engine.races[SKATING], EOG, reset)
+			UpdateGame(engine.races[DIVING], tt.goal, reset)
+
+			beam, err := NewBeamSearch(3, 8)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := beam.Plan(engine); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
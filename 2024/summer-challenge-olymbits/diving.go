@@ -1,5 +1,7 @@
 package main
 
+import "github.com/mendel/codingames/olymbits/rules"
+
 const (
 	L = 'l'
 	D = 'D'
@@ -66,6 +68,19 @@ func (d Diving) Eval(cmd Command, playerIdx int) int {
 	return d.normalize(float64(score), float64(min), float64(max))
 }
 
+func (d Diving) Step(cmds [3]Command) (string, [7]int) {
+	return rules.Diving(d.gpu, d.regs, cmds)
+}
+
+// remaining is the number of goal letters left.
+func (d Diving) remaining() int {
+	if d.isEOG() {
+		return 0
+	}
+
+	return len(d.gpu)
+}
+
 type Diver struct {
 	Contestant
 }
@@ -81,3 +96,7 @@ func (d Diver) points() int {
 func (d Diver) combo() int {
 	return *d.regs[1]
 }
+
+func (d Diving) standing() rules.Standing {
+	return rules.DivingStanding
+}
@@ -6,12 +6,16 @@ import (
 	"math"
 	"os"
 	"strings"
+
+	"github.com/mendel/codingames/olymbits/rules"
 )
 
 type Engine struct {
 	teamTotal [3]int
 	playerIdx int
 	races     map[string]Game
+	planner   Planner
+	checker   *Reconciler
 }
 
 func NewEngine(playerIdx int, games ...Game) Engine {
@@ -40,56 +44,74 @@ func NewEngine(playerIdx int, games ...Game) Engine {
 
 func (e Engine) total(idx int) int { return e.teamTotal[idx] }
 
-func (e Engine) ListenAndServe(scanner *bufio.Scanner) {
+// WithPlanner returns a copy of the engine deciding its commands with p
+// instead of Exec.
+func (e Engine) WithPlanner(p Planner) Engine {
+	e.planner = p
+	return e
+}
+
+// WithReconciler returns a copy of the engine checking every scoreInfo
+// against its games with r.
+func (e Engine) WithReconciler(r *Reconciler) Engine {
+	e.checker = r
+	return e
+}
+
+func (e Engine) decide() Command {
+	if e.planner != nil {
+		return e.planner.Plan(e)
+	}
+
+	return e.Exec()
+}
+
+// Order in which the referee sends the mini-games, both in scoreInfo and
+// in the registers lines.
+var Order = [4]string{HURDLING, ARCHERY, SKATING, DIVING}
+
+// ListenAndServe plays turns read from scanner until the end of the input,
+// or until the reconciler reports an error, which is returned.
+func (e Engine) ListenAndServe(scanner *bufio.Scanner) error {
 	nbPlayers := 3
 
 	for {
+		var scores [3][4]Score
+
 		for i := 0; i < nbPlayers; i++ {
-			scanner.Scan()
+			if !scanner.Scan() {
+				return scanner.Err()
+			}
 			scoreInfo := strings.Fields(scanner.Text())
 
-			hurdlingScore := Score{
-				GOLD:   toInt(scoreInfo[1]),
-				SILVER: toInt(scoreInfo[2]),
-				BRONZE: toInt(scoreInfo[3]),
-			}
-			archeryScore := Score{
-				GOLD:   toInt(scoreInfo[4]),
-				SILVER: toInt(scoreInfo[5]),
-				BRONZE: toInt(scoreInfo[6]),
-			}
-			skatingScore := Score{
-				GOLD:   toInt(scoreInfo[7]),
-				SILVER: toInt(scoreInfo[8]),
-				BRONZE: toInt(scoreInfo[9]),
-			}
-			divingScore := Score{
-				GOLD:   toInt(scoreInfo[10]),
-				SILVER: toInt(scoreInfo[11]),
-				BRONZE: toInt(scoreInfo[12]),
+			e.teamTotal[i] = toInt(scoreInfo[0])
+			for g, key := range Order {
+				scores[i][g] = NewScore(
+					toInt(scoreInfo[1+3*g]),
+					toInt(scoreInfo[2+3*g]),
+					toInt(scoreInfo[3+3*g]),
+				)
+				UpdatePlayer(e.races[key].Player(i), scores[i][g])
 			}
+		}
 
-			e.teamTotal[i] = toInt(scoreInfo[0])
-			UpdatePlayer(e.races[HURDLING].Player(i), hurdlingScore)
-			UpdatePlayer(e.races[ARCHERY].Player(i), archeryScore)
-			UpdatePlayer(e.races[SKATING].Player(i), skatingScore)
-			UpdatePlayer(e.races[DIVING].Player(i), divingScore)
+		for _, key := range Order {
+			gpu, regs := ParseState(scanner)
+			UpdateGame(e.races[key], gpu, regs)
 		}
 
-		gpu, regs := ParseState(scanner)
-		UpdateGame(e.races[HURDLING], gpu, regs)
-		gpu, regs = ParseState(scanner)
-		UpdateGame(e.races[ARCHERY], gpu, regs)
-		gpu, regs = ParseState(scanner)
-		UpdateGame(e.races[SKATING], gpu, regs)
-		gpu, regs = ParseState(scanner)
-		UpdateGame(e.races[DIVING], gpu, regs)
+		if e.checker != nil {
+			if err := e.checker.Reconcile(e, e.teamTotal, scores); err != nil {
+				return err
+			}
+		}
 
-		action := e.Exec()
+		action := e.decide()
 
 		fmt.Println(action)
 	}
 }
+
 func geometricMean(totalScore int, numGames int) float64 {
 	if totalScore <= 0 {
 		return 0
@@ -97,6 +119,9 @@ func geometricMean(totalScore int, numGames int) float64 {
 	return math.Pow(float64(totalScore), 1.0/float64(numGames))
 }
 
+// Commands lists every action a player may output, in evaluation order.
+var Commands = rules.Commands
+
 func (e Engine) Exec() Command {
 	bestAction := LEFT
 	maxBias := -1 << 31
@@ -104,10 +129,18 @@ func (e Engine) Exec() Command {
 	// Calculate the geometric mean using the total score
 	geomMean := geometricMean(e.total(e.playerIdx), len(e.races))
 
-	for _, cmd := range []Command{UP, DOWN, LEFT, RIGHT} {
+	for _, cmd := range Commands {
 		totalBias := 0
 
 		for key, game := range e.races {
+			// nothing we do on a reset turn affects the game
+			if game.isEOG() {
+				if debug {
+					fmt.Fprintf(os.Stderr, "GAME: %8s, RESET, RUNS: %d\n", key, len(game.Runs()))
+				}
+				continue
+			}
+
 			bias := game.Eval(cmd, e.playerIdx)
 			playerScore := game.Player(e.playerIdx).Score()
 			place := game.Place(game.Player(e.playerIdx))
@@ -121,17 +154,14 @@ func (e Engine) Exec() Command {
 				bias = int(float64(bias) * 0.01)
 			}
 
-			// Adjust bias based on potential to win or improve in the game
-			opponentScores := []int{}
+			// Consider the highest opponent score in the game
+			maxOpponentScore := 0
 			for i := 0; i < 3; i++ {
 				if i != e.playerIdx {
-					opponentScores = append(opponentScores, game.Player(i).Score())
+					maxOpponentScore = max(maxOpponentScore, game.Player(i).Score())
 				}
 			}
 
-			// Consider the highest opponent score in the game
-			maxOpponentScore := max(opponentScores)
-
 			// If player's score is significantly lower than the highest opponent score, deprioritize the game
 			if playerScore < maxOpponentScore-10 {
 				bias = int(float64(bias) * 0.5)
@@ -146,9 +176,9 @@ func (e Engine) Exec() Command {
 				bias *= 5
 			}
 
-			// if bias > 0 {
-			fmt.Fprintf(os.Stderr, "GAME: %8s, ACTION: %5s, BIAS: %5d, PLAYER SCORE: %3d, PLACE: %d, GEOM MEAN: %.2f\n", key, cmd, bias, playerScore, place, geomMean)
-			// }
+			if debug {
+				fmt.Fprintf(os.Stderr, "GAME: %8s, ACTION: %5s, BIAS: %5d, PLAYER SCORE: %3d, PLACE: %d, GEOM MEAN: %.2f, NEXT RUN: %d\n", key, cmd, bias, playerScore, place, geomMean, TurnsUntilStart(game))
+			}
 			totalBias += bias
 		}
 
@@ -160,14 +190,3 @@ func (e Engine) Exec() Command {
 
 	return bestAction
 }
-
-// Helper function to find the maximum value in a slice
-func max(slice []int) int {
-	maxValue := slice[0]
-	for _, value := range slice {
-		if value > maxValue {
-			maxValue = value
-		}
-	}
-	return maxValue
-}
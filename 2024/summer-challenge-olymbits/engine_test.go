@@ -0,0 +1,53 @@
+package main
+
+import "testing"
+
+// midGame returns an engine whose games are in the middle of a run
+func midGame() Engine {
+	engine := NewEngine(
+		0,
+		NewHurdling(NewHurdler(), NewHurdler(), NewHurdler()),
+		NewArchery(NewArcher(), NewArcher(), NewArcher()),
+		NewSkating(NewSkater(), NewSkater(), NewSkater()),
+		NewDiving(NewDiver(), NewDiver(), NewDiver()),
+	)
+
+	turns := [4]struct {
+		gpu  string
+		regs [7]int
+	}{
+		{".....#...#....#.....#....#....", [7]int{4, 7, 3, 0, 0, 2, -1}},
+		{"5738291", [7]int{-3, 8, 2, -5, 0, 11, -1}},
+		{"ULDR", [7]int{9, 12, 7, 1, 3, 0, 8}},
+		{"UDDLRUU", [7]int{3, 6, 1, 1, 3, 0, -1}},
+	}
+	for g, key := range Order {
+		UpdateGame(engine.races[key], turns[g].gpu, turns[g].regs)
+	}
+
+	for i := 0; i < 3; i++ {
+		for _, key := range Order {
+			UpdatePlayer(engine.races[key].Player(i), NewScore(i, 1, 2-i))
+		}
+	}
+
+	return engine
+}
+
+func TestExecAllocs(t *testing.T) {
+	engine := midGame()
+
+	if allocs := testing.AllocsPerRun(100, func() { engine.Exec() }); allocs != 0 {
+		t.Errorf("Exec allocates %.1f times per call, want 0", allocs)
+	}
+}
+
+func BenchmarkExec(b *testing.B) {
+	engine := midGame()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		engine.Exec()
+	}
+}
@@ -1,6 +1,10 @@
 package main
 
-import "math"
+import (
+	"math"
+
+	"github.com/mendel/codingames/olymbits/rules"
+)
 
 type Game interface {
 	Place(Player) int
@@ -13,29 +17,122 @@ type Game interface {
 	// Eval rates simulated move for the player ranged from 0 to 100
 	Eval(cmd Command, playerIdx int) int
 
+	// Step simulates a turn in which every player performs its command and
+	// returns the registers of the following turn
+	Step(cmds [3]Command) (gpu string, regs [7]int)
+
+	// Runs returns the history of the runs played so far, the last one
+	// being in progress unless the game is on a reset turn
+	Runs() []Run
+
+	// snapshot returns the current registers of the game
+	snapshot() Snapshot
+
+	// load replaces the registers without tracking runs
+	load(s Snapshot)
+
+	// finish records the final placings of the current run
+	finish(places [3]int)
+
+	// standing ranks the players in the registers of a finished run
+	standing() rules.Standing
+
+	// remaining estimates the number of turns left in the current run
+	remaining() int
+
 	// isEOG checks if the current session has ended
 	isEOG() bool
 }
 
+// Run records the setup and outcome of one run of a mini-game.
+type Run struct {
+	// Setup is the GPU at the start of the run: the track, the winds or the
+	// diving goal
+	Setup string
+	// Start holds the registers at the start of the run
+	Start [7]int
+	// Places holds the final placing of every player, zero while running
+	Places [3]int
+	// Turns played in the run
+	Turns int
+}
+
 type Race struct {
 	gpu     string
 	regs    [7]int
 	players [3]Player
+	runs    []Run
 }
 
 func (r Race) normalize(n, min, max float64) int {
 	return int(math.Ceil(100 * normalize(n, min, max)))
 }
 
+// Update loads the registers and starts a new run when the game leaves a
+// reset turn.
 func (r *Race) Update(gpu string, regs [7]int) {
+	restart := r.isEOG() || len(r.runs) == 0
+
 	r.gpu = gpu
 	r.regs = regs
+
+	if r.isEOG() {
+		return
+	}
+
+	if restart {
+		r.runs = append(r.runs, Run{Setup: gpu, Start: regs})
+	}
+	r.runs[len(r.runs)-1].Turns++
+}
+
+func (r Race) Runs() []Run {
+	return r.runs
+}
+
+func (r *Race) load(s Snapshot) {
+	r.gpu = s.gpu
+	r.regs = s.regs
+}
+
+func (r *Race) finish(places [3]int) {
+	if len(r.runs) == 0 {
+		return
+	}
+	r.runs[len(r.runs)-1].Places = places
 }
 
 func (r Race) Player(idx int) Player {
 	return r.players[idx]
 }
 
+func (r Race) snapshot() Snapshot {
+	return Snapshot{r.gpu, r.regs}
+}
+
 func (r Race) isEOG() bool {
 	return r.gpu == EOG
 }
+
+// TurnsUntilStart returns the number of turns before the next run of g
+// begins: 1 on a reset turn, otherwise the turns left in the current run
+// plus the reset turn.
+func TurnsUntilStart(g Game) int {
+	if g.isEOG() {
+		return 1
+	}
+
+	return g.remaining() + 1
+}
+
+// NextRun guesses the registers of the next run of g from the start of the
+// last recorded one, since the new setup is only known once it begins.
+func NextRun(g Game) (Snapshot, bool) {
+	runs := g.Runs()
+	if len(runs) == 0 {
+		return Snapshot{}, false
+	}
+
+	last := runs[len(runs)-1]
+	return Snapshot{last.Setup, last.Start}, true
+}
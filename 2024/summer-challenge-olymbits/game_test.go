@@ -0,0 +1,102 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/mendel/codingames/olymbits/rules"
+)
+
+// TestRunsRecordFinalPlacings plays scripted runs whose last turn changes
+// the placings, and checks the run history the game records
+func TestRunsRecordFinalPlacings(t *testing.T) {
+	tests := []struct {
+		name   string
+		game   Game
+		turn   rules.Turn
+		gpu    string
+		regs   [7]int
+		cmds   [][3]Command
+		places [3]int
+	}{
+		{
+			name: "hurdling",
+			game: NewHurdling(NewHurdler(), NewHurdler(), NewHurdler()),
+			turn: rules.Hurdling,
+			gpu:  "......",
+			regs: [7]int{0, 0, 0, 0, 0, 0, -1},
+			cmds: [][3]Command{
+				{RIGHT, DOWN, LEFT},
+				{LEFT, RIGHT, LEFT},
+			},
+			places: [3]int{2, 1, 3},
+		},
+		{
+			name: "archery",
+			game: NewArchery(NewArcher(), NewArcher(), NewArcher()),
+			turn: rules.Archery,
+			gpu:  "12",
+			regs: [7]int{5, 0, 5, 0, 5, 0, -1},
+			cmds: [][3]Command{
+				{LEFT, LEFT, RIGHT},
+				{LEFT, RIGHT, LEFT},
+			},
+			places: [3]int{1, 3, 2},
+		},
+		{
+			name: "skating",
+			game: NewSkating(NewSkater(), NewSkater(), NewSkater()),
+			turn: rules.Skating,
+			gpu:  "UDLR",
+			regs: [7]int{0, 0, 0, 0, 0, 0, 2},
+			cmds: [][3]Command{
+				{RIGHT, DOWN, UP},
+				{UP, RIGHT, DOWN},
+			},
+			places: [3]int{2, 1, 3},
+		},
+		{
+			name: "diving",
+			game: NewDiving(NewDiver(), NewDiver(), NewDiver()),
+			turn: rules.Diving,
+			gpu:  "UD",
+			regs: [7]int{0, 0, 0, 0, 0, 0, -1},
+			cmds: [][3]Command{
+				{UP, UP, DOWN},
+				{DOWN, UP, DOWN},
+			},
+			places: [3]int{1, 2, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gpu, regs := tt.gpu, tt.regs
+			UpdateGame(tt.game, gpu, regs)
+
+			for _, cmds := range tt.cmds {
+				gpu, regs = tt.turn(gpu, regs, cmds)
+				UpdateGame(tt.game, gpu, regs)
+			}
+
+			if gpu != EOG {
+				t.Fatalf("run not over after the script, gpu %q", gpu)
+			}
+
+			runs := tt.game.Runs()
+			if len(runs) != 1 {
+				t.Fatalf("got %d runs, want 1", len(runs))
+			}
+
+			want := Run{Setup: tt.gpu, Start: tt.regs, Places: tt.places, Turns: len(tt.cmds)}
+			if runs[0] != want {
+				t.Errorf("got run %+v, want %+v", runs[0], want)
+			}
+
+			// the turn after the reset one starts a new run
+			UpdateGame(tt.game, tt.gpu, tt.regs)
+			if runs := tt.game.Runs(); len(runs) != 2 || runs[1].Places != [3]int{} {
+				t.Errorf("got runs %+v after the restart, want a new run in progress", runs)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package main
 
+import "github.com/mendel/codingames/olymbits/rules"
+
 const (
-	DOT    = '.'
-	HURDLE = '#'
+	DOT    = rules.DOT
+	HURDLE = rules.HURDLE
 )
 
 type Hurdling struct {
@@ -23,31 +25,6 @@ func NewHurdling(hurdlers ...*Hurdler) *Hurdling {
 	return &h
 }
 
-func (h Hurdling) willStun(cmd Command, pos int) bool {
-	if len(h.gpu) < pos {
-		return false
-	}
-
-	res := false
-	for i := 1; i <= Steps[cmd]; i++ {
-		res = res || checkForHurdle(h.gpu[pos:], i)
-	}
-
-	return res
-}
-
-func (h Hurdling) calcMove(track string, move int) int {
-	score := move
-	for i := 0; i < move && i < len(track); i++ {
-		if track[i] == HURDLE {
-			score = i
-			break
-		}
-	}
-
-	return score
-}
-
 func (h Hurdling) Place(p Player) int {
 	place := 1
 
@@ -66,12 +43,7 @@ func (h Hurdling) Place(p Player) int {
 	return place
 }
 
-var Steps = map[Command]int{
-	LEFT:  1,
-	UP:    2,
-	DOWN:  2,
-	RIGHT: 3,
-}
+var Steps = rules.Steps
 
 func (h Hurdling) Eval(cmd Command, playerIdx int) int {
 	if h.isEOG() {
@@ -84,17 +56,38 @@ func (h Hurdling) Eval(cmd Command, playerIdx int) int {
 		return 0
 	}
 
-	score := 0
+	// run the command through the rules so the bot has a single hurdle model
+	pos, stun := rules.Hurdle(h.gpu, player.pos(), 0, cmd)
 
-	if h.willStun(cmd, player.pos()) {
+	score := pos - player.pos()
+	if stun > 0 {
 		score -= 3
 	}
 
-	score += h.calcMove(h.gpu[player.pos():], Steps[cmd])
-
 	return h.normalize(float64(score), -2, 3)
 }
 
+func (h Hurdling) Step(cmds [3]Command) (string, [7]int) {
+	return rules.Hurdling(h.gpu, h.regs, cmds)
+}
+
+// remaining estimates the turns left as the time the closest hurdler needs
+// to finish running 3 spaces per turn.
+func (h Hurdling) remaining() int {
+	if h.isEOG() {
+		return 0
+	}
+
+	finish := len(h.gpu) - 1
+	turns := finish
+	for i := range h.players {
+		pos, stun := h.regs[i], max(h.regs[i+3], 0)
+		turns = min(turns, (finish-pos+2)/3+stun)
+	}
+
+	return turns
+}
+
 type Hurdler struct {
 	Contestant
 }
@@ -110,3 +103,7 @@ func (h Hurdler) pos() int {
 func (h Hurdler) stuns() int {
 	return *h.regs[1]
 }
+
+func (h Hurdling) standing() rules.Standing {
+	return rules.HurdlingStanding
+}
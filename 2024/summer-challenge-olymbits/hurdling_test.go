@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+// TestHurdlingEval checks that Eval follows rules.Hurdle: a hurdle UP jumps
+// over costs nothing, a hurdle the command lands on costs the stun
+func TestHurdlingEval(t *testing.T) {
+	tests := []struct {
+		name  string
+		gpu   string
+		cmd   Command
+		stuns bool
+	}{
+		{name: "UP jumps over", gpu: ".#....", cmd: UP},
+		{name: "UP lands on", gpu: "..#...", cmd: UP, stuns: true},
+		{name: "LEFT hits", gpu: ".#....", cmd: LEFT, stuns: true},
+		{name: "RIGHT hits", gpu: "...#..", cmd: RIGHT, stuns: true},
+	}
+
+	eval := func(gpu string, cmd Command) int {
+		h := NewHurdling(NewHurdler(), NewHurdler(), NewHurdler())
+		h.Update(gpu, [7]int{0, 0, 0, 0, 0, 0, -1})
+		return h.Eval(cmd, 0)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, clear := eval(tt.gpu, tt.cmd), eval("......", tt.cmd)
+			if tt.stuns && got >= clear {
+				t.Errorf("got %d, want less than %d on a clear track", got, clear)
+			}
+			if !tt.stuns && got != clear {
+				t.Errorf("got %d, want %d as on a clear track", got, clear)
+			}
+		})
+	}
+}
@@ -2,11 +2,31 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"os"
 )
 
+var (
+	planner   = flag.String("planner", "greedy", "decision procedure: greedy or beam")
+	beamDepth = flag.Int("beam-depth", 4, "number of turns planned by the beam search")
+	beamWidth = flag.Int("beam-width", 16, "number of states kept per beam search depth")
+	strict    = flag.Bool("strict", false, "stop when the referee's medals diverge from our rules model")
+)
+
 func main() {
+	flag.Parse()
+
+	var plan Planner
+	if *planner == "beam" {
+		beam, err := NewBeamSearch(*beamDepth, *beamWidth)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "ERROR:", err)
+			os.Exit(2)
+		}
+		plan = beam
+	}
+
 	scanner := bufio.NewScanner(os.Stdin)
 	scanner.Buffer(make([]byte, 1000000), 1000000)
 
@@ -26,5 +46,14 @@ func main() {
 		NewDiving(NewDiver(), NewDiver(), NewDiver()),
 	)
 
-	engine.ListenAndServe(scanner)
+	engine = engine.WithReconciler(NewReconciler(*strict))
+
+	if plan != nil {
+		engine = engine.WithPlanner(plan)
+	}
+
+	if err := engine.ListenAndServe(scanner); err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR:", err)
+		os.Exit(1)
+	}
 }
@@ -1,109 +1,354 @@
+// Code generated by codingame-golang-merger from github.com/mendel/codingames/olymbits. DO NOT EDIT.
+
 package main
 
 import (
-	"os"
-	"strings"
-	"math"
 	"bufio"
+	"errors"
+	"flag"
 	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
 )
 
+// github.com/mendel/codingames/olymbits/rules/archery.go
+
+// Bound is the absolute value the archery coordinates are clamped to.
+const rules_Bound = 20
+
+// Shoot moves a cursor by the wind in the direction of cmd.
+func rules_Shoot(x, y, wind int, cmd rules_Command) (int, int) {
+	switch cmd {
+	case rules_LEFT:
+		x -= wind
+	case rules_UP:
+		y -= wind
+	case rules_RIGHT:
+		x += wind
+	case rules_DOWN:
+		y += wind
+	}
+
+	return rules_clamp(x, -rules_Bound, rules_Bound), rules_clamp(y, -rules_Bound, rules_Bound)
+}
+
+// Archery is the Turn of the archery, the GPU lists the winds left and the
+// first one is consumed.
+func rules_Archery(winds string, regs [7]int, cmds [3]rules_Command) (string, [7]int) {
+	if winds == rules_EOG || len(winds) == 0 {
+		return winds, regs
+	}
+
+	wind := int(winds[0] - '0')
+	for i, cmd := range cmds {
+		regs[2*i], regs[2*i+1] = rules_Shoot(regs[2*i], regs[2*i+1], wind, cmd)
+	}
+
+	if len(winds) == 1 {
+		return rules_EOG, regs
+	}
+
+	return winds[1:], regs
+}
+
+// ArcheryStanding ranks the archers by their distance to the center, the
+// closest first.
+func rules_ArcheryStanding(regs [7]int, i int) int {
+	x, y := regs[2*i], regs[2*i+1]
+	return -(x*x + y*y)
+}
+
+// github.com/mendel/codingames/olymbits/rules/diving.go
+
+// Dive grows the combo of a diver matching the goal letter and adds it to
+// the points, or resets it otherwise.
+func rules_Dive(goal byte, points, combo int, cmd rules_Command) (int, int) {
+	if len(cmd) == 0 || cmd[0] != goal {
+		return points, 0
+	}
+
+	return points + combo + 1, combo + 1
+}
+
+// Diving is the Turn of the diving, the GPU holds the goal left and its
+// first letter is consumed.
+func rules_Diving(goal string, regs [7]int, cmds [3]rules_Command) (string, [7]int) {
+	if goal == rules_EOG || len(goal) == 0 {
+		return goal, regs
+	}
+
+	for i, cmd := range cmds {
+		regs[i], regs[i+3] = rules_Dive(goal[0], regs[i], regs[i+3], cmd)
+	}
+
+	if len(goal) == 1 {
+		return rules_EOG, regs
+	}
+
+	return goal[1:], regs
+}
+
+// DivingStanding ranks the divers by their points.
+func rules_DivingStanding(regs [7]int, i int) int {
+	return regs[i]
+}
+
+// github.com/mendel/codingames/olymbits/rules/hurdling.go
+
 const (
-	ARCHERY			= "ARCHERY"
-	DOWN		Command	= "DOWN"
-	SKATING			= "SKATING"
-	LEFT		Command	= "LEFT"
-	SILVER		Medal	= 1
-	BRONZE		Medal	= 2
-	DOT			= '.'
-	HURDLE			= '#'
-	EOG			= "GAME_OVER"
-	GOLD		Medal	= 0
-	L			= 'l'
-	U			= 'U'
-	HURDLING		= "HURDLING"
-	DIVING			= "DIVING"
-	RIGHT		Command	= "RIGHT"
-	UP		Command	= "UP"
-	D			= 'D'
-	R			= 'R'
+	rules_DOT    = '.'
+	rules_HURDLE = '#'
 )
 
-var (
-	Origin	Coord	= Coord{0, 0}
-	Steps		= map[Command]int{LEFT: 1, UP: 2, DOWN: 2, RIGHT: 3}
-	Ranks		= [4][2]int{{1, -1}, {2, 0}, {2, 1}, {3, 2}}
+// StunTurns is the number of turns a hurdler hitting a hurdle stays still.
+const rules_StunTurns = 3
+
+var rules_Steps = map[rules_Command]int{
+	rules_LEFT:  1,
+	rules_UP:    2,
+	rules_DOWN:  2,
+	rules_RIGHT: 3,
+}
+
+// Hurdle moves a hurdler space by space, stopping on the first hurdle hit.
+// UP jumps over the next space and only checks the landing one.
+func rules_Hurdle(track string, pos, stun int, cmd rules_Command) (int, int) {
+	if stun > 0 {
+		return pos, stun - 1
+	}
+
+	finish := len(track) - 1
+	for step := 1; step <= rules_Steps[cmd] && pos < finish; step++ {
+		pos++
+		if cmd == rules_UP && step == 1 {
+			continue
+		}
+
+		if track[pos] == rules_HURDLE {
+			return pos, rules_StunTurns
+		}
+	}
+
+	return pos, 0
+}
+
+// Hurdling is the Turn of the hurdle race. The run ends as soon as a
+// hurdler reaches the last space.
+func rules_Hurdling(track string, regs [7]int, cmds [3]rules_Command) (string, [7]int) {
+	if track == rules_EOG {
+		return track, regs
+	}
+
+	done := false
+	for i, cmd := range cmds {
+		regs[i], regs[i+3] = rules_Hurdle(track, regs[i], regs[i+3], cmd)
+		done = done || regs[i] >= len(track)-1
+	}
+
+	if done {
+		return rules_EOG, regs
+	}
+
+	return track, regs
+}
+
+// HurdlingStanding ranks the hurdlers by the spaces they ran.
+func rules_HurdlingStanding(regs [7]int, i int) int {
+	return regs[i]
+}
+
+// github.com/mendel/codingames/olymbits/rules/rules.go
+
+type rules_Command string
+
+const (
+	rules_LEFT  rules_Command = "LEFT"
+	rules_DOWN  rules_Command = "DOWN"
+	rules_RIGHT rules_Command = "RIGHT"
+	rules_UP    rules_Command = "UP"
 )
 
-type (
-	Diver		struct{ Contestant }
-	Hurdler		struct{ Contestant }
-	Strategy	interface{ Apply(game Game) string }
-	Diving		struct{ Race }
-	Race		struct {
-		gpu	string
-		regs	[7]int
-		players	[3]Player
-	}
-	Skating	struct{ Race }
-	Command	string
-	Archery	struct{ Race }
-	Archer	struct{ Contestant }
-	Game	interface {
-		Place(Player) int
-		Player(idx int) Player
-		Update(gpu string, regs [7]int)
-		Eval(cmd Command, playerIdx int) int
-		isEOG() bool
-	}
-	Hurdling	struct{ Race }
-	Score		map[Medal]int
-	Skater		struct{ Contestant }
-	Coord		struct {
-		x	int
-		y	int
-	}
-	Engine	struct {
-		teamTotal	[3]int
-		playerIdx	int
-		races		map[string]Game
-	}
-	Player	interface {
-		Update(score Score)
-		Score() int
-	}
-	Contestant	struct {
-		regs	[2]*int
-		score	Score
-	}
-	StrategyFunc	func(g Game) string
-	Medal		int
+// Commands lists every action a player may output.
+var rules_Commands = [4]rules_Command{rules_UP, rules_DOWN, rules_LEFT, rules_RIGHT}
+
+// EOG is the GPU of a mini-game on its reset turn.
+const rules_EOG = "GAME_OVER"
+
+// Turn simulates one turn of a mini-game: every player performs its command
+// and the registers of the following turn are returned.
+type rules_Turn func(gpu string, regs [7]int, cmds [3]rules_Command) (string, [7]int)
+
+// Standing rates player i in the registers of a finished run, the higher
+// the better.
+type rules_Standing func(regs [7]int, i int) int
+
+// Places returns the placing of every player at the end of a run. Tied
+// players share the best of their placings, like the medals they win.
+func rules_Places(standing rules_Standing, regs [7]int) [3]int {
+	var places [3]int
+	for i := range places {
+		places[i] = 1
+		for j := range places {
+			if standing(regs, j) > standing(regs, i) {
+				places[i]++
+			}
+		}
+	}
+	return places
+}
+
+func rules_clamp(a, min, max int) int {
+	if a > max {
+		return max
+	} else if a < min {
+		return min
+	}
+	return a
+}
+
+// github.com/mendel/codingames/olymbits/rules/skating.go
+
+const (
+	// TrackLength is the number of spaces of the cyclical skating track.
+	rules_TrackLength = 10
+	// MaxRisk is the risk at which a skater falls.
+	rules_MaxRisk = 5
+	// FallTurns is the number of turns a fallen skater stays still.
+	rules_FallTurns = 2
+	// CollisionRisk is the risk added to skaters sharing a space.
+	rules_CollisionRisk = 2
 )
 
+// Ranks holds the spaces travelled and the risk taken by the command at
+// every index of the risk order.
+var rules_Ranks = [4][2]int{
+	{1, -1},
+	{2, 0},
+	{2, 1},
+	{3, 2},
+}
+
+// Rank returns the spaces travelled and the risk taken by cmd for the given
+// risk order, e.g. "ULDR".
+func rules_Rank(order string, cmd rules_Command) (int, int) {
+	if len(cmd) == 0 {
+		return 0, 0
+	}
+
+	i := strings.IndexByte(order, cmd[0])
+	if i < 0 || i >= len(rules_Ranks) {
+		return 0, 0
+	}
+
+	return rules_Ranks[i][0], rules_Ranks[i][1]
+}
+
+// Skating is the Turn of the roller speed skating. Skaters landing on the
+// space of another one raise their risk, reaching MaxRisk makes them fall
+// for FallTurns turns, shown as a negative risk. The next risk order is
+// random, so the current one is kept.
+func rules_Skating(order string, regs [7]int, cmds [3]rules_Command) (string, [7]int) {
+	if order == rules_EOG {
+		return order, regs
+	}
+
+	moved := [3]bool{}
+	for i, cmd := range cmds {
+		if regs[i+3] < 0 {
+			regs[i+3]++
+			continue
+		}
+
+		spaces, risk := rules_Rank(order, cmd)
+		regs[i] += spaces
+		regs[i+3] = max(regs[i+3]+risk, 0)
+		moved[i] = true
+	}
+
+	for i := range cmds {
+		for j := range cmds {
+			if i == j || !moved[i] || regs[i]%rules_TrackLength != regs[j]%rules_TrackLength {
+				continue
+			}
+
+			regs[i+3] += rules_CollisionRisk
+		}
+	}
+
+	for i := range cmds {
+		if regs[i+3] >= rules_MaxRisk {
+			regs[i+3] = -rules_FallTurns
+		}
+	}
+
+	regs[6]--
+	if regs[6] <= 0 {
+		return rules_EOG, regs
+	}
+
+	return order, regs
+}
+
+// SkatingStanding ranks the skaters by the spaces they travelled.
+func rules_SkatingStanding(regs [7]int, i int) int {
+	return regs[i]
+}
+
+// github.com/mendel/codingames/olymbits/archery.go
+
+type Archery struct {
+	Race
+}
+
+func NewArchery(archers ...*Archer) *Archery {
+	a := Archery{}
+
+	j := 0
+	for i := range archers {
+		archers[i].regs = [2]*int{&a.regs[j], &a.regs[j+1]}
+		j += 2
+		a.players[i] = archers[i]
+	}
+
+	return &a
+}
+
 func (a Archery) wind() int {
-	return toInt(string(a.gpu[0]))
+	return int(a.gpu[0] - '0')
 }
+
 func (a Archery) Place(p Player) int {
 	place := 1
+
 	for _, player := range a.players {
 		if player == p {
 			continue
 		}
+
 		player := player.(*Archer)
 		p := p.(*Archer)
 		if dist(player.coord(), Origin) > dist(p.coord(), Origin) {
 			place++
 		}
 	}
+
 	return place
 }
+
 func (a Archery) Eval(cmd Command, playerIdx int) int {
 	if a.isEOG() {
 		return 0
 	}
+
 	player := a.Player(playerIdx).(*Archer)
+
 	score := 0.0
+
 	coord := player.coord()
+
 	switch cmd {
 	case LEFT:
 		coord.x -= a.wind()
@@ -114,105 +359,480 @@ func (a Archery) Eval(cmd Command, playerIdx int) int {
 	case DOWN:
 		coord.y += a.wind()
 	}
+
 	coord.x = int(clamp(float64(coord.x), -20, 20))
 	coord.y = int(clamp(float64(coord.y), -20, 20))
+
 	delta := math.Sqrt(float64(player.coord().x*player.coord().x + player.coord().y*player.coord().y))
 	deltaEval := math.Sqrt(float64(coord.x*coord.x + coord.y*coord.y))
+
 	score = delta - deltaEval
 	return int(100 * normalize(float64(score), -9, 9+float64(len(a.gpu)-1)))
 }
+
+func (a Archery) Step(cmds [3]Command) (string, [7]int) {
+	return rules_Archery(a.gpu, a.regs, cmds)
+}
+
+// remaining is the number of winds left.
+func (a Archery) remaining() int {
+	if a.isEOG() {
+		return 0
+	}
+
+	return len(a.gpu)
+}
+
+var Origin Coord = Coord{0, 0}
+
+type Coord struct {
+	x int // x coordinate
+	y int // y coordinate
+}
+
+type Archer struct {
+	Contestant
+}
+
 func NewArcher() *Archer {
 	return &Archer{}
 }
+
 func (a Archer) coord() Coord {
-	return Coord{x: *a.regs[0], y: *a.regs[1]}
+	return Coord{
+		x: *a.regs[0],
+		y: *a.regs[1],
+	}
 }
-func NewArchery(archers ...*Archer) *Archery {
-	a := Archery{}
-	j := 0
-	for i := range archers {
-		archers[i].regs = [2]*int{&a.regs[j], &a.regs[j+1]}
-		j += 2
-		a.players[i] = archers[i]
+
+func (a Archery) standing() rules_Standing {
+	return rules_ArcheryStanding
+}
+
+// github.com/mendel/codingames/olymbits/beam.go
+
+// Planner is a decision procedure the engine can use instead of Exec.
+type Planner interface {
+	Plan(e Engine) Command
+}
+
+// Snapshot holds the registers of one mini-game at a given turn.
+type Snapshot struct {
+	gpu  string
+	regs [7]int
+}
+
+type beamNode struct {
+	states [4]Snapshot
+	evals  [4]int
+	line   []Command
+	value  float64
+}
+
+// BeamSearch plans a line of our commands over all four mini-games at once.
+// Every depth advances each game with our command and a predicted command
+// for the opponents, and only the best width nodes are kept.
+type BeamSearch struct {
+	depth int
+	width int
+
+	// next guesses the registers of the run following a reset turn
+	next [4]Snapshot
+
+	// scratch games are loaded with a node's registers to step and rate it
+	scratch [4]Game
+}
+
+// NewBeamSearch returns a beam search planning depth turns ahead and keeping
+// width nodes per depth, both at least 1.
+func NewBeamSearch(depth, width int) (*BeamSearch, error) {
+	if depth < 1 || width < 1 {
+		return nil, fmt.Errorf("beam depth and width must be at least 1, got %d and %d", depth, width)
 	}
-	return &a
+
+	return &BeamSearch{
+		depth: depth,
+		width: width,
+		scratch: [4]Game{
+			NewHurdling(NewHurdler(), NewHurdler(), NewHurdler()),
+			NewArchery(NewArcher(), NewArcher(), NewArcher()),
+			NewSkating(NewSkater(), NewSkater(), NewSkater()),
+			NewDiving(NewDiver(), NewDiver(), NewDiver()),
+		},
+	}, nil
+}
+
+func (b *BeamSearch) Plan(e Engine) Command {
+	root := beamNode{}
+	for g, key := range Order {
+		root.states[g] = e.races[key].snapshot()
+		b.next[g], _ = NextRun(e.races[key])
+	}
+
+	beam := []beamNode{root}
+	for depth := 1; depth <= b.depth; depth++ {
+		next := make([]beamNode, 0, len(beam)*len(Commands))
+
+		for _, node := range beam {
+			// the opponents don't depend on our command, they are
+			// predicted once per node
+			predicted := b.predict(node, e.playerIdx)
+			for _, cmd := range Commands {
+				next = append(next, b.advance(e, node, predicted, cmd, depth))
+			}
+		}
+
+		sort.SliceStable(next, func(i, j int) bool {
+			return next[i].value > next[j].value
+		})
+
+		if len(next) > b.width {
+			next = next[:b.width]
+		}
+		beam = next
+	}
+
+	best := beam[0]
+	fmt.Fprintf(os.Stderr, "BEAM: %s, VALUE: %.3f\n", formatLine(best.line), best.value)
+
+	return best.line[0]
+}
+
+// advance plays cmd for us and the predicted commands for the opponents
+// from node, and rates the resulting node.
+func (b *BeamSearch) advance(e Engine, node beamNode, predicted [3]Command, cmd Command, depth int) beamNode {
+	child := beamNode{
+		evals: node.evals,
+		line:  append(node.line[:len(node.line):len(node.line)], cmd),
+		value: 1,
+	}
+
+	cmds := predicted
+	cmds[e.playerIdx] = cmd
+
+	for g, game := range b.scratch {
+		game.load(node.states[g])
+
+		// a game on its reset turn restarts on the next one, plan its new
+		// run as a replay of the last one
+		if game.isEOG() && b.next[g].gpu != "" {
+			child.states[g] = b.next[g]
+		} else {
+			child.evals[g] += game.Eval(cmd, e.playerIdx)
+			gpu, regs := game.Step(cmds)
+			child.states[g] = Snapshot{gpu, regs}
+		}
+
+		// Eval ranges from -100 to 100, mapping it to [0, 1] lets it stand
+		// for the chance of improving the mini-game score
+		gain := float64(child.evals[g]+100*depth) / float64(200*depth)
+		score := e.races[Order[g]].Player(e.playerIdx).Score()
+		child.value *= float64(score) + gain
+	}
+
+	return child
+}
+
+// predict guesses every opponent's command as the one maximizing its total
+// Eval over the mini-games loaded into scratch from node.
+func (b *BeamSearch) predict(node beamNode, playerIdx int) [3]Command {
+	cmds := [3]Command{}
+
+	for i := range cmds {
+		if i == playerIdx {
+			continue
+		}
+
+		best := -1 << 31
+		for _, cmd := range Commands {
+			total := 0
+			for g, game := range b.scratch {
+				game.load(node.states[g])
+				total += game.Eval(cmd, i)
+			}
+
+			if total > best {
+				best = total
+				cmds[i] = cmd
+			}
+		}
+	}
+
+	return cmds
+}
+
+func formatLine(line []Command) string {
+	var sb strings.Builder
+	for i, cmd := range line {
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(string(cmd))
+	}
+	return sb.String()
+}
+
+// github.com/mendel/codingames/olymbits/diving.go
+
+const (
+	L = 'l'
+	D = 'D'
+	R = 'R'
+	U = 'U'
+)
+
+type Diving struct {
+	Race
 }
+
 func NewDiving(divers ...*Diver) *Diving {
 	d := Diving{}
+
 	j := 0
 	for i := range divers {
 		divers[i].regs = [2]*int{&d.regs[j], &d.regs[j+3]}
 		j++
 		d.players[i] = divers[i]
 	}
+
 	return &d
 }
+
 func (d Diving) diver(idx int) *Diver {
 	return d.Player(idx).(*Diver)
 }
+
 func (d Diving) Place(p Player) int {
 	place := 1
+
 	for _, player := range d.players {
 		if player == p {
 			continue
 		}
+
 		player := player.(*Diver)
 		p := p.(*Diver)
 		if player.points()+player.combo() > p.points() {
 			place++
 		}
 	}
+
 	return place
 }
+
 func (d Diving) Eval(cmd Command, playerIdx int) int {
 	if d.isEOG() {
 		return 0
 	}
+
 	player := d.diver(playerIdx)
+
 	score := 0
 	if len(d.gpu) > 0 && d.gpu[0] != cmd[0] {
 		score = -player.combo()
 	} else {
 		score = player.combo() + 1
 	}
+
+	// max := player.combo()*len(d.gpu) + (len(d.gpu)*(len(d.gpu)-1))/2 + 1
 	max := player.combo() + 1
 	min := -player.combo()
 	return d.normalize(float64(score), float64(min), float64(max))
 }
+
+func (d Diving) Step(cmds [3]Command) (string, [7]int) {
+	return rules_Diving(d.gpu, d.regs, cmds)
+}
+
+// remaining is the number of goal letters left.
+func (d Diving) remaining() int {
+	if d.isEOG() {
+		return 0
+	}
+
+	return len(d.gpu)
+}
+
+type Diver struct {
+	Contestant
+}
+
 func NewDiver() *Diver {
 	return &Diver{}
 }
+
 func (d Diver) points() int {
 	return *d.regs[0]
 }
+
 func (d Diver) combo() int {
 	return *d.regs[1]
 }
+
+func (d Diving) standing() rules_Standing {
+	return rules_DivingStanding
+}
+
+// github.com/mendel/codingames/olymbits/engine.go
+
+type Engine struct {
+	teamTotal [3]int
+	playerIdx int
+	races     map[string]Game
+	planner   Planner
+	checker   *Reconciler
+}
+
+func NewEngine(playerIdx int, games ...Game) Engine {
+	races := make(map[string]Game, len(games))
+
+	for _, game := range games {
+		switch game.(type) {
+		case *Hurdling:
+			races[HURDLING] = game
+		case *Diving:
+			races[DIVING] = game
+		case *Skating:
+			races[SKATING] = game
+		case *Archery:
+			races[ARCHERY] = game
+		default:
+			fmt.Fprintln(os.Stderr, "UNKNOWN TYPE OF GAME:", game)
+		}
+	}
+
+	return Engine{
+		races:     races,
+		playerIdx: playerIdx,
+	}
+}
+
+func (e Engine) total(idx int) int { return e.teamTotal[idx] }
+
+// WithPlanner returns a copy of the engine deciding its commands with p
+// instead of Exec.
+func (e Engine) WithPlanner(p Planner) Engine {
+	e.planner = p
+	return e
+}
+
+// WithReconciler returns a copy of the engine checking every scoreInfo
+// against its games with r.
+func (e Engine) WithReconciler(r *Reconciler) Engine {
+	e.checker = r
+	return e
+}
+
+func (e Engine) decide() Command {
+	if e.planner != nil {
+		return e.planner.Plan(e)
+	}
+
+	return e.Exec()
+}
+
+// Order in which the referee sends the mini-games, both in scoreInfo and
+// in the registers lines.
+var Order = [4]string{HURDLING, ARCHERY, SKATING, DIVING}
+
+// ListenAndServe plays turns read from scanner until the end of the input,
+// or until the reconciler reports an error, which is returned.
+func (e Engine) ListenAndServe(scanner *bufio.Scanner) error {
+	nbPlayers := 3
+
+	for {
+		var scores [3][4]Score
+
+		for i := 0; i < nbPlayers; i++ {
+			if !scanner.Scan() {
+				return scanner.Err()
+			}
+			scoreInfo := strings.Fields(scanner.Text())
+
+			e.teamTotal[i] = toInt(scoreInfo[0])
+			for g, key := range Order {
+				scores[i][g] = NewScore(
+					toInt(scoreInfo[1+3*g]),
+					toInt(scoreInfo[2+3*g]),
+					toInt(scoreInfo[3+3*g]),
+				)
+				UpdatePlayer(e.races[key].Player(i), scores[i][g])
+			}
+		}
+
+		for _, key := range Order {
+			gpu, regs := ParseState(scanner)
+			UpdateGame(e.races[key], gpu, regs)
+		}
+
+		if e.checker != nil {
+			if err := e.checker.Reconcile(e, e.teamTotal, scores); err != nil {
+				return err
+			}
+		}
+
+		action := e.decide()
+
+		fmt.Println(action)
+	}
+}
+
+func geometricMean(totalScore int, numGames int) float64 {
+	if totalScore <= 0 {
+		return 0
+	}
+	return math.Pow(float64(totalScore), 1.0/float64(numGames))
+}
+
+// Commands lists every action a player may output, in evaluation order.
+var Commands = rules_Commands
+
 func (e Engine) Exec() Command {
 	bestAction := LEFT
 	maxBias := -1 << 31
+
+	// Calculate the geometric mean using the total score
 	geomMean := geometricMean(e.total(e.playerIdx), len(e.races))
-	for _, cmd := range []Command{UP, DOWN, LEFT, RIGHT} {
+
+	for _, cmd := range Commands {
 		totalBias := 0
+
 		for key, game := range e.races {
+			// nothing we do on a reset turn affects the game
+			if game.isEOG() {
+				if debug {
+					fmt.Fprintf(os.Stderr, "GAME: %8s, RESET, RUNS: %d\n", key, len(game.Runs()))
+				}
+				continue
+			}
+
 			bias := game.Eval(cmd, e.playerIdx)
 			playerScore := game.Player(e.playerIdx).Score()
 			place := game.Place(game.Player(e.playerIdx))
+
+			// // Calculate score importance based on geometric mean
 			if float64(playerScore) <= geomMean {
+				// Prioritize games with scores below the geometric mean
 				bias = int(float64(bias) * 5)
 			} else {
+				// Deprioritize games with scores above the geometric mean
 				bias = int(float64(bias) * 0.01)
 			}
-			opponentScores := []int{}
+
+			// Consider the highest opponent score in the game
+			maxOpponentScore := 0
 			for i := 0; i < 3; i++ {
 				if i != e.playerIdx {
-					opponentScores = append(opponentScores, game.Player(i).Score())
+					maxOpponentScore = max(maxOpponentScore, game.Player(i).Score())
 				}
 			}
-			maxOpponentScore := max(opponentScores)
+
+			// If player's score is significantly lower than the highest opponent score, deprioritize the game
 			if playerScore < maxOpponentScore-10 {
 				bias = int(float64(bias) * 0.5)
 			}
+
+			// Prioritize games where the player is in the highest place
 			if place == 1 {
 				bias = int(float64(bias) * 3)
 			} else if place == 2 {
@@ -220,187 +840,608 @@ func (e Engine) Exec() Command {
 			} else if place == 3 {
 				bias *= 5
 			}
-			fmt.Fprintf(os.Stderr, "GAME: %8s, ACTION: %5s, BIAS: %5d, PLAYER SCORE: %3d, PLACE: %d, GEOM MEAN: %.2f\n", key, cmd, bias, playerScore, place, geomMean)
+
+			if debug {
+				fmt.Fprintf(os.Stderr, "GAME: %8s, ACTION: %5s, BIAS: %5d, PLAYER SCORE: %3d, PLACE: %d, GEOM MEAN: %.2f, NEXT RUN: %d\n", key, cmd, bias, playerScore, place, geomMean, TurnsUntilStart(game))
+			}
 			totalBias += bias
 		}
+
 		if totalBias > maxBias {
 			maxBias = totalBias
 			bestAction = cmd
 		}
 	}
+
 	return bestAction
 }
-func max(slice []int) int {
-	maxValue := slice[0]
-	for _, value := range slice {
-		if value > maxValue {
-			maxValue = value
-		}
-	}
-	return maxValue
-}
-func NewEngine(playerIdx int, games ...Game) Engine {
-	races := make(map[string]Game, len(games))
-	for _, game := range games {
-		switch game.(type) {
-		case *Hurdling:
-			races[HURDLING] = game
-		case *Diving:
-			races[DIVING] = game
-		case *Skating:
-			races[SKATING] = game
-		case *Archery:
-			races[ARCHERY] = game
-		default:
-			fmt.Fprintln(os.Stderr, "UNKNOWN TYPE OF GAME:", game)
-		}
-	}
-	return Engine{races: races, playerIdx: playerIdx}
-}
-func (e Engine) total(idx int) int {
-	return e.teamTotal[idx]
+
+// github.com/mendel/codingames/olymbits/game.go
+
+type Game interface {
+	Place(Player) int
+
+	Player(idx int) Player
+
+	// Update state of the game
+	Update(gpu string, regs [7]int)
+
+	// Eval rates simulated move for the player ranged from 0 to 100
+	Eval(cmd Command, playerIdx int) int
+
+	// Step simulates a turn in which every player performs its command and
+	// returns the registers of the following turn
+	Step(cmds [3]Command) (gpu string, regs [7]int)
+
+	// Runs returns the history of the runs played so far, the last one
+	// being in progress unless the game is on a reset turn
+	Runs() []Run
+
+	// snapshot returns the current registers of the game
+	snapshot() Snapshot
+
+	// load replaces the registers without tracking runs
+	load(s Snapshot)
+
+	// finish records the final placings of the current run
+	finish(places [3]int)
+
+	// standing ranks the players in the registers of a finished run
+	standing() rules_Standing
+
+	// remaining estimates the number of turns left in the current run
+	remaining() int
+
+	// isEOG checks if the current session has ended
+	isEOG() bool
 }
-func (e Engine) ListenAndServe(scanner *bufio.Scanner) {
-	nbPlayers := 3
-	for {
-		for i := 0; i < nbPlayers; i++ {
-			scanner.Scan()
-			scoreInfo := strings.Fields(scanner.Text())
-			hurdlingScore := Score{GOLD: toInt(scoreInfo[1]), SILVER: toInt(scoreInfo[2]), BRONZE: toInt(scoreInfo[3])}
-			archeryScore := Score{GOLD: toInt(scoreInfo[4]), SILVER: toInt(scoreInfo[5]), BRONZE: toInt(scoreInfo[6])}
-			skatingScore := Score{GOLD: toInt(scoreInfo[7]), SILVER: toInt(scoreInfo[8]), BRONZE: toInt(scoreInfo[9])}
-			divingScore := Score{GOLD: toInt(scoreInfo[10]), SILVER: toInt(scoreInfo[11]), BRONZE: toInt(scoreInfo[12])}
-			e.teamTotal[i] = toInt(scoreInfo[0])
-			UpdatePlayer(e.races[HURDLING].Player(i), hurdlingScore)
-			UpdatePlayer(e.races[ARCHERY].Player(i), archeryScore)
-			UpdatePlayer(e.races[SKATING].Player(i), skatingScore)
-			UpdatePlayer(e.races[DIVING].Player(i), divingScore)
-		}
-		gpu, regs := ParseState(scanner)
-		UpdateGame(e.races[HURDLING], gpu, regs)
-		gpu, regs = ParseState(scanner)
-		UpdateGame(e.races[ARCHERY], gpu, regs)
-		gpu, regs = ParseState(scanner)
-		UpdateGame(e.races[SKATING], gpu, regs)
-		gpu, regs = ParseState(scanner)
-		UpdateGame(e.races[DIVING], gpu, regs)
-		action := e.Exec()
-		fmt.Println(action)
-	}
+
+// Run records the setup and outcome of one run of a mini-game.
+type Run struct {
+	// Setup is the GPU at the start of the run: the track, the winds or the
+	// diving goal
+	Setup string
+	// Start holds the registers at the start of the run
+	Start [7]int
+	// Places holds the final placing of every player, zero while running
+	Places [3]int
+	// Turns played in the run
+	Turns int
 }
-func geometricMean(totalScore int, numGames int) float64 {
-	if totalScore <= 0 {
-		return 0
-	}
-	return math.Pow(float64(totalScore), 1.0/float64(numGames))
+
+type Race struct {
+	gpu     string
+	regs    [7]int
+	players [3]Player
+	runs    []Run
 }
+
 func (r Race) normalize(n, min, max float64) int {
 	return int(math.Ceil(100 * normalize(n, min, max)))
 }
+
+// Update loads the registers and starts a new run when the game leaves a
+// reset turn.
 func (r *Race) Update(gpu string, regs [7]int) {
+	restart := r.isEOG() || len(r.runs) == 0
+
 	r.gpu = gpu
 	r.regs = regs
+
+	if r.isEOG() {
+		return
+	}
+
+	if restart {
+		r.runs = append(r.runs, Run{Setup: gpu, Start: regs})
+	}
+	r.runs[len(r.runs)-1].Turns++
+}
+
+func (r Race) Runs() []Run {
+	return r.runs
+}
+
+func (r *Race) load(s Snapshot) {
+	r.gpu = s.gpu
+	r.regs = s.regs
+}
+
+func (r *Race) finish(places [3]int) {
+	if len(r.runs) == 0 {
+		return
+	}
+	r.runs[len(r.runs)-1].Places = places
 }
+
 func (r Race) Player(idx int) Player {
 	return r.players[idx]
 }
+
+func (r Race) snapshot() Snapshot {
+	return Snapshot{r.gpu, r.regs}
+}
+
 func (r Race) isEOG() bool {
 	return r.gpu == EOG
 }
-func NewHurdler() *Hurdler {
-	return &Hurdler{}
+
+// TurnsUntilStart returns the number of turns before the next run of g
+// begins: 1 on a reset turn, otherwise the turns left in the current run
+// plus the reset turn.
+func TurnsUntilStart(g Game) int {
+	if g.isEOG() {
+		return 1
+	}
+
+	return g.remaining() + 1
 }
-func (h Hurdler) pos() int {
-	return *h.regs[0]
+
+// NextRun guesses the registers of the next run of g from the start of the
+// last recorded one, since the new setup is only known once it begins.
+func NextRun(g Game) (Snapshot, bool) {
+	runs := g.Runs()
+	if len(runs) == 0 {
+		return Snapshot{}, false
+	}
+
+	last := runs[len(runs)-1]
+	return Snapshot{last.Setup, last.Start}, true
 }
-func (h Hurdler) stuns() int {
-	return *h.regs[1]
+
+// github.com/mendel/codingames/olymbits/hurdling.go
+
+const (
+	DOT    = rules_DOT
+	HURDLE = rules_HURDLE
+)
+
+type Hurdling struct {
+	Race
 }
+
 func NewHurdling(hurdlers ...*Hurdler) *Hurdling {
 	h := Hurdling{}
+
 	j := 0
 	for i := range hurdlers {
 		hurdlers[i].regs = [2]*int{&h.regs[j], &h.regs[j+3]}
 		j++
+
 		h.players[i] = hurdlers[i]
 	}
+
 	return &h
 }
-func (h Hurdling) willStun(cmd Command, pos int) bool {
-	if len(h.gpu) < pos {
-		return false
-	}
-	res := false
-	for i := 1; i <= Steps[cmd]; i++ {
-		res = res || checkForHurdle(h.gpu[pos:], i)
-	}
-	return res
-}
-func (h Hurdling) calcMove(track string, move int) int {
-	score := move
-	for i := 0; i < move && i < len(track); i++ {
-		if track[i] == HURDLE {
-			score = i
-			break
-		}
-	}
-	return score
-}
+
 func (h Hurdling) Place(p Player) int {
 	place := 1
+
 	for _, player := range h.players {
 		if player == p {
 			continue
 		}
+
 		player := player.(*Hurdler)
 		p := p.(*Hurdler)
 		if player.pos() > p.pos() {
 			place++
 		}
 	}
+
 	return place
 }
+
+var Steps = rules_Steps
+
 func (h Hurdling) Eval(cmd Command, playerIdx int) int {
 	if h.isEOG() {
 		return 0
 	}
+
 	player := h.players[playerIdx].(*Hurdler)
+
 	if player.stuns() > 0 {
 		return 0
 	}
-	score := 0
-	if h.willStun(cmd, player.pos()) {
+
+	// run the command through the rules so the bot has a single hurdle model
+	pos, stun := rules_Hurdle(h.gpu, player.pos(), 0, cmd)
+
+	score := pos - player.pos()
+	if stun > 0 {
 		score -= 3
 	}
-	score += h.calcMove(h.gpu[player.pos():], Steps[cmd])
+
 	return h.normalize(float64(score), -2, 3)
 }
+
+func (h Hurdling) Step(cmds [3]Command) (string, [7]int) {
+	return rules_Hurdling(h.gpu, h.regs, cmds)
+}
+
+// remaining estimates the turns left as the time the closest hurdler needs
+// to finish running 3 spaces per turn.
+func (h Hurdling) remaining() int {
+	if h.isEOG() {
+		return 0
+	}
+
+	finish := len(h.gpu) - 1
+	turns := finish
+	for i := range h.players {
+		pos, stun := h.regs[i], max(h.regs[i+3], 0)
+		turns = min(turns, (finish-pos+2)/3+stun)
+	}
+
+	return turns
+}
+
+type Hurdler struct {
+	Contestant
+}
+
+func NewHurdler() *Hurdler {
+	return &Hurdler{}
+}
+
+func (h Hurdler) pos() int {
+	return *h.regs[0]
+}
+
+func (h Hurdler) stuns() int {
+	return *h.regs[1]
+}
+
+func (h Hurdling) standing() rules_Standing {
+	return rules_HurdlingStanding
+}
+
+// github.com/mendel/codingames/olymbits/main.go
+
+var (
+	planner   = flag.String("planner", "greedy", "decision procedure: greedy or beam")
+	beamDepth = flag.Int("beam-depth", 4, "number of turns planned by the beam search")
+	beamWidth = flag.Int("beam-width", 16, "number of states kept per beam search depth")
+	strict    = flag.Bool("strict", false, "stop when the referee's medals diverge from our rules model")
+)
+
+func main() {
+	flag.Parse()
+
+	var plan Planner
+	if *planner == "beam" {
+		beam, err := NewBeamSearch(*beamDepth, *beamWidth)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "ERROR:", err)
+			os.Exit(2)
+		}
+		plan = beam
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 1000000), 1000000)
+
+	var playerIdx int
+	scanner.Scan()
+	fmt.Sscan(scanner.Text(), &playerIdx)
+
+	var nbGames int
+	scanner.Scan()
+	fmt.Sscan(scanner.Text(), &nbGames)
+
+	engine := NewEngine(
+		playerIdx,
+		NewHurdling(NewHurdler(), NewHurdler(), NewHurdler()),
+		NewArchery(NewArcher(), NewArcher(), NewArcher()),
+		NewSkating(NewSkater(), NewSkater(), NewSkater()),
+		NewDiving(NewDiver(), NewDiver(), NewDiver()),
+	)
+
+	engine = engine.WithReconciler(NewReconciler(*strict))
+
+	if plan != nil {
+		engine = engine.WithPlanner(plan)
+	}
+
+	if err := engine.ListenAndServe(scanner); err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR:", err)
+		os.Exit(1)
+	}
+}
+
+// github.com/mendel/codingames/olymbits/mcts.go
+
+// import (
+// 	"fmt"
+// 	"math"
+// 	"math/rand"
+// 	"time"
+// )
+
+// // Define constants
+// const (
+// 	MaxFirstTurnTime = 1000 * time.Millisecond
+// 	MaxTurnTime      = 50 * time.Millisecond
+// )
+
+// // TreeNode represents a node in the MCTS tree
+// type TreeNode struct {
+// 	state       *Engine
+// 	parent      *TreeNode
+// 	children    []*TreeNode
+// 	visits      int
+// 	totalReward float64
+// 	action      Command
+// }
+
+// func NewTreeNode(state *Engine, parent *TreeNode, action Command) *TreeNode {
+// 	return &TreeNode{
+// 		state:       state,
+// 		parent:      parent,
+// 		action:      action,
+// 		visits:      0,
+// 		children:    nil,
+// 		totalReward: 0.0,
+// 	}
+// }
+
+// func (n *TreeNode) UCT() float64 {
+// 	if n.visits == 0 {
+// 		return math.Inf(1)
+// 	}
+// 	return n.totalReward/float64(n.visits) + math.Sqrt(2*math.Log(float64(n.parent.visits))/float64(n.visits))
+// }
+
+// func (n *TreeNode) SelectBestChild() *TreeNode {
+// 	var bestChild *TreeNode
+// 	bestValue := -math.Inf(1)
+// 	for _, child := range n.children {
+// 		uctValue := child.UCT()
+// 		if uctValue > bestValue {
+// 			bestValue = uctValue
+// 			bestChild = child
+// 		}
+// 	}
+// 	return bestChild
+// }
+
+// func (n *TreeNode) Expand() {
+// 	commands := []Command{UP, DOWN, LEFT, RIGHT}
+// 	for _, cmd := range commands {
+// 		newState := n.state.Copy()
+// 		newState.ApplyCommand(cmd)
+// 		childNode := NewTreeNode(newState, n, cmd)
+// 		n.children = append(n.children, childNode)
+// 	}
+// }
+
+// func (n *TreeNode) Simulate() float64 {
+// 	simulatedState := n.state.Copy()
+// 	for !simulatedState.IsGameOver() {
+// 		randomCmd := n.getRandomCommand()
+// 		simulatedState.ApplyCommand(randomCmd)
+// 	}
+// 	return simulatedState.Evaluate()
+// }
+
+// func (n *TreeNode) Backpropagate(reward float64) {
+// 	currentNode := n
+// 	for currentNode != nil {
+// 		currentNode.visits++
+// 		currentNode.totalReward += reward
+// 		currentNode = currentNode.parent
+// 	}
+// }
+
+// func (n *TreeNode) getRandomCommand() Command {
+// 	commands := []Command{UP, DOWN, LEFT, RIGHT}
+// 	return commands[rand.Intn(len(commands))]
+// }
+
+// func MonteCarloTreeSearch(initialState *Engine, maxTime time.Duration) Command {
+// 	root := NewTreeNode(initialState, nil, LEFT)
+// 	simulations := 0
+// 	results := make(chan bool)
+
+// 	endTime := time.Now().Add(maxTime)
+// 	go func() {
+// 		for {
+// 			if time.Now().After(endTime) {
+// 				results <- true
+// 				return
+// 			}
+// 			node := root
+// 			for len(node.children) != 0 {
+// 				node = node.SelectBestChild()
+// 			}
+// 			if node.visits > 0 {
+// 				node.Expand()
+// 				node = node.SelectBestChild()
+// 			}
+// 			reward := node.Simulate()
+// 			node.Backpropagate(reward)
+// 			simulations++
+// 		}
+// 	}()
+
+// 	select {
+// 	case <-results:
+// 		// Timeout reached, stop MCTS
+// 	}
+
+// 	bestChild := root.SelectBestChild()
+// 	fmt.Printf("Simulations: %d\n", simulations)
+// 	return bestChild.action
+// }
+
+// github.com/mendel/codingames/olymbits/player.go
+
+type Player interface {
+	// Update state of the player
+	Update(score Score)
+
+	// Score earned by player, calculated based on the formula
+	// 3*gold + silver medals
+	Score() int
+}
+
+type Contestant struct {
+	regs  [2]*int
+	score Score
+}
+
+// Score earned in mini-game
+// calculated by formula: 3*gold + silver
 func (c Contestant) Score() int {
 	return c.score.Calc()
 }
+
 func (c *Contestant) Update(score Score) {
 	c.score = score
 }
-func (s Score) Calc() int {
-	return s[GOLD]*3 + s[SILVER]
+
+// github.com/mendel/codingames/olymbits/reconcile.go
+
+// Reconciler checks the scoreInfo sent by the referee against our own model
+// of the rules: the final score must be the product of the mini-game scores,
+// medals may only change when a run ends, and the medals awarded must match
+// the placings the rules package gives the registers of the reset turn, as
+// recorded in the runs of our games.
+type Reconciler struct {
+	// strict makes Reconcile report mismatches as an error instead of only
+	// logging them
+	strict bool
+
+	prev    [3][4]Score
+	prevEOG [4]bool
+	started bool
+}
+
+func NewReconciler(strict bool) *Reconciler {
+	return &Reconciler{strict: strict}
+}
+
+// Reconcile checks the scores of a turn once the registers of the same turn
+// have been loaded into the engine's games.
+func (r *Reconciler) Reconcile(e Engine, totals [3]int, scores [3][4]Score) error {
+	var errs []error
+
+	for i := range scores {
+		product := 1
+		for g := range Order {
+			product *= scores[i][g].Calc()
+		}
+
+		if product != totals[i] {
+			errs = append(errs, fmt.Errorf("player %d: total %d, product of mini-game scores %d", i, totals[i], product))
+		}
+	}
+
+	for g, key := range Order {
+		game := e.races[key]
+		ended := game.isEOG() && !r.prevEOG[g]
+		r.prevEOG[g] = game.isEOG()
+
+		if !r.started {
+			continue
+		}
+
+		var places [3]int
+		if runs := game.Runs(); ended && len(runs) > 0 {
+			places = runs[len(runs)-1].Places
+		}
+
+		for i := range scores {
+			delta := NewScore(
+				scores[i][g][GOLD]-r.prev[i][g][GOLD],
+				scores[i][g][SILVER]-r.prev[i][g][SILVER],
+				scores[i][g][BRONZE]-r.prev[i][g][BRONZE],
+			)
+
+			if delta == (Score{}) {
+				if ended {
+					errs = append(errs, fmt.Errorf("%s: run ended without a medal for player %d", key, i))
+				}
+				continue
+			}
+
+			if !ended {
+				errs = append(errs, fmt.Errorf("%s: medals of player %d changed by %v while no run ended", key, i, delta))
+				continue
+			}
+
+			if places[i] == 0 {
+				continue
+			}
+
+			want := Score{}
+			want[Medal(places[i]-1)] = 1
+			if delta != want {
+				errs = append(errs, fmt.Errorf("%s: player %d won %v, our placing %d predicts %v", key, i, delta, places[i], want))
+			}
+		}
+	}
+
+	r.prev = scores
+	r.started = true
+
+	for _, err := range errs {
+		fmt.Fprintln(os.Stderr, "RECONCILE:", err)
+	}
+
+	if !r.strict {
+		return nil
+	}
+
+	return errors.Join(errs...)
 }
+
+// github.com/mendel/codingames/olymbits/score.go
+
+// Score holds the number of medals earned in a mini-game, indexed by Medal.
+type Score [3]int
+
 func NewScore(gold, silver, bronze int) Score {
-	return Score{GOLD: gold, SILVER: silver, BRONZE: bronze}
+	return Score{
+		GOLD:   gold,
+		SILVER: silver,
+		BRONZE: bronze,
+	}
 }
-func NewSkater() *Skater {
-	return &Skater{}
+
+func (s Score) Calc() int {
+	return s[GOLD]*3 + s[SILVER]
 }
-func (s Skater) spaces() int {
-	return *s.regs[0]
+
+// github.com/mendel/codingames/olymbits/skating.go
+
+type Skating struct {
+	Race
 }
-func (s Skater) risk() int {
-	return *s.regs[1]
+
+func NewSkating(skaters ...*Skater) *Skating {
+	s := Skating{}
+
+	j := 0
+	for i := range skaters {
+		skaters[i].regs = [2]*int{&s.regs[j], &s.regs[j+3]}
+		j++
+		s.players[i] = skaters[i]
+	}
+
+	return &s
 }
+
 func (s Skating) turnsLeft() int {
 	return s.regs[6]
 }
+
+var Ranks = rules_Ranks
+
 func (s Skating) rank(cmd Command) [2]int {
 	i := strings.IndexRune(s.gpu, rune(cmd[0]))
+
 	return Ranks[i]
 }
 func (s Skating) evalCollision(pos, playerIdx int) float64 {
@@ -412,49 +1453,70 @@ func (s Skating) evalCollision(pos, playerIdx int) float64 {
 			if pos != p.spaces()%10 {
 				continue
 			}
+
 			score += 2
 		}
+
 		if i != playerIdx {
-			oppPositions := []int{(p.spaces() + 1) % 10, (p.spaces() + 2) % 10, (p.spaces() + 2) % 10, (p.spaces() + 3) % 10}
+			// Estimate opponent's possible new positions
+			oppPositions := []int{
+				(p.spaces() + 1) % 10,
+				(p.spaces() + 2) % 10,
+				(p.spaces() + 2) % 10,
+				(p.spaces() + 3) % 10,
+			}
+
 			for _, oppPos := range oppPositions {
 				if pos != oppPos {
 					continue
 				}
+
 				score++
 			}
 		}
 	}
 	return 4 * normalize(float64(score), 0, 8)
 }
+
 func (s Skating) skater(idx int) *Skater {
 	return s.Player(idx).(*Skater)
 }
+
 func (s Skating) Place(p Player) int {
 	place := 1
+
 	for _, player := range s.players {
 		if player == p {
 			continue
 		}
+
 		player := player.(*Skater)
 		p := p.(*Skater)
 		if player.spaces() > p.spaces() {
 			place++
 		}
 	}
+
 	return place
 }
+
 func (s Skating) Eval(cmd Command, playerIdx int) int {
 	if s.isEOG() {
 		return 0
 	}
+
 	player := s.skater(playerIdx)
+
 	if player.risk() < 0 {
 		return 0
 	}
+
 	rank := s.rank(cmd)
 	deltaSpaces := rank[0]
 	deltaRisk := rank[1]
+
 	collision := s.evalCollision((player.spaces() + deltaSpaces), playerIdx)
+
 	score := 0.0
 	if risk := deltaRisk + player.risk(); risk > 4 {
 		score -= float64(risk)
@@ -464,49 +1526,131 @@ func (s Skating) Eval(cmd Command, playerIdx int) int {
 		score -= collision
 		score -= float64(player.risk()-deltaRisk) * 0.25
 	}
+
 	return s.normalize(float64(score), -3, 4)
 }
-func NewSkating(skaters ...*Skater) *Skating {
-	s := Skating{}
-	j := 0
-	for i := range skaters {
-		skaters[i].regs = [2]*int{&s.regs[j], &s.regs[j+3]}
-		j++
-		s.players[i] = skaters[i]
+
+func (s Skating) Step(cmds [3]Command) (string, [7]int) {
+	return rules_Skating(s.gpu, s.regs, cmds)
+}
+
+func (s Skating) remaining() int {
+	if s.isEOG() {
+		return 0
 	}
-	return &s
+
+	return s.turnsLeft()
+}
+
+type Skater struct {
+	Contestant
+}
+
+func NewSkater() *Skater {
+	return &Skater{}
+}
+
+func (s Skater) spaces() int {
+	return *s.regs[0]
+}
+func (s Skater) risk() int {
+	return *s.regs[1]
+}
+
+func (s Skating) standing() rules_Standing {
+	return rules_SkatingStanding
+}
+
+// github.com/mendel/codingames/olymbits/strategy.go
+
+// Strategy interface represents a strategy for determining actions.
+// It allows different strategies to be implemented and applied to games.
+type Strategy interface {
+	// Apply the strategy to the game and return the chosen action.
+	Apply(game Game) string
 }
+
+// StrategyFunc is a function type that implements the Strategy interface.
+// It allows a function to be used as a strategy by implementing the Apply method.
+type StrategyFunc func(g Game) string
+
+// Apply executes the strategy function on the game and returns the chosen action.
 func (sf StrategyFunc) Apply(game Game) string {
 	return sf(game)
 }
+
+// github.com/mendel/codingames/olymbits/types.go
+
+// debug enables per-evaluation traces on stderr. It is a constant so the
+// tracing code is compiled out of the hot path when disabled.
+const debug = false
+
+const (
+	ARCHERY  = "ARCHERY"
+	HURDLING = "HURDLING"
+	SKATING  = "SKATING"
+	DIVING   = "DIVING"
+)
+const EOG = rules_EOG
+
+type Command = rules_Command
+
+const (
+	LEFT  = rules_LEFT
+	DOWN  = rules_DOWN
+	RIGHT = rules_RIGHT
+	UP    = rules_UP
+)
+
+type Medal int
+
+const (
+	GOLD   Medal = 0
+	SILVER Medal = 1
+	BRONZE Medal = 2
+)
+
 func normalize(n, min, max float64) float64 {
 	return 2*((n-min)/(max-min)) - 1
 }
+
+// UpdateGame loads the registers of a turn into g, recording the final
+// placings while the registers of a run that just ended are still there.
 func UpdateGame(g Game, gpu string, regs [7]int) {
+	ended := gpu == EOG && !g.isEOG() && len(g.Runs()) > 0
+
 	g.Update(gpu, regs)
+
+	// the registers of the reset turn hold the last moves of the run
+	if ended {
+		g.finish(rules_Places(g.standing(), regs))
+	}
 }
+
 func UpdatePlayer(p Player, score Score) {
 	p.Update(score)
 }
+
 func ParseState(scanner *bufio.Scanner) (gpu string, regs [7]int) {
 	scanner.Scan()
-	fmt.Sscan(scanner.Text(), &gpu, &regs[0], &regs[1], &regs[2], &regs[3], &regs[4], &regs[5], &regs[6])
+	fmt.Sscan(scanner.Text(), &gpu,
+		&regs[0], &regs[1], &regs[2], &regs[3], &regs[4], &regs[5], &regs[6])
+
 	return
 }
+
 func toInt(str string) int {
-	var result int
-	fmt.Sscan(str, &result)
+	result, _ := strconv.Atoi(str)
 	return result
 }
-func checkForHurdle(track string, distance int) bool {
-	return len(track) > distance && track[distance] == HURDLE
-}
+
 func calcScore(remained, move int) int {
 	if remained < move && remained > 0 {
 		return remained
 	}
 	return move
 }
+
 func clamp(a, min, max float64) float64 {
 	if a > max {
 		a = max
@@ -515,18 +1659,7 @@ func clamp(a, min, max float64) float64 {
 	}
 	return a
 }
+
 func dist(a, b Coord) float64 {
 	return math.Sqrt(float64((a.x-b.x)*(a.x-b.x)) + float64((a.y-b.y)*(a.y-b.y)))
 }
-func main() {
-	scanner := bufio.NewScanner(os.Stdin)
-	scanner.Buffer(make([]byte, 1000000), 1000000)
-	var playerIdx int
-	scanner.Scan()
-	fmt.Sscan(scanner.Text(), &playerIdx)
-	var nbGames int
-	scanner.Scan()
-	fmt.Sscan(scanner.Text(), &nbGames)
-	engine := NewEngine(playerIdx, NewHurdling(NewHurdler(), NewHurdler(), NewHurdler()), NewArchery(NewArcher(), NewArcher(), NewArcher()), NewSkating(NewSkater(), NewSkater(), NewSkater()), NewDiving(NewDiver(), NewDiver(), NewDiver()))
-	engine.ListenAndServe(scanner)
-}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Reconciler checks the scoreInfo sent by the referee against our own model
+// of the rules: the final score must be the product of the mini-game scores,
+// medals may only change when a run ends, and the medals awarded must match
+// the placings the rules package gives the registers of the reset turn, as
+// recorded in the runs of our games.
+type Reconciler struct {
+	// strict makes Reconcile report mismatches as an error instead of only
+	// logging them
+	strict bool
+
+	prev    [3][4]Score
+	prevEOG [4]bool
+	started bool
+}
+
+func NewReconciler(strict bool) *Reconciler {
+	return &Reconciler{strict: strict}
+}
+
+// Reconcile checks the scores of a turn once the registers of the same turn
+// have been loaded into the engine's games.
+func (r *Reconciler) Reconcile(e Engine, totals [3]int, scores [3][4]Score) error {
+	var errs []error
+
+	for i := range scores {
+		product := 1
+		for g := range Order {
+			product *= scores[i][g].Calc()
+		}
+
+		if product != totals[i] {
+			errs = append(errs, fmt.Errorf("player %d: total %d, product of mini-game scores %d", i, totals[i], product))
+		}
+	}
+
+	for g, key := range Order {
+		game := e.races[key]
+		ended := game.isEOG() && !r.prevEOG[g]
+		r.prevEOG[g] = game.isEOG()
+
+		if !r.started {
+			continue
+		}
+
+		var places [3]int
+		if runs := game.Runs(); ended && len(runs) > 0 {
+			places = runs[len(runs)-1].Places
+		}
+
+		for i := range scores {
+			delta := NewScore(
+				scores[i][g][GOLD]-r.prev[i][g][GOLD],
+				scores[i][g][SILVER]-r.prev[i][g][SILVER],
+				scores[i][g][BRONZE]-r.prev[i][g][BRONZE],
+			)
+
+			if delta == (Score{}) {
+				if ended {
+					errs = append(errs, fmt.Errorf("%s: run ended without a medal for player %d", key, i))
+				}
+				continue
+			}
+
+			if !ended {
+				errs = append(errs, fmt.Errorf("%s: medals of player %d changed by %v while no run ended", key, i, delta))
+				continue
+			}
+
+			if places[i] == 0 {
+				continue
+			}
+
+			want := Score{}
+			want[Medal(places[i]-1)] = 1
+			if delta != want {
+				errs = append(errs, fmt.Errorf("%s: player %d won %v, our placing %d predicts %v", key, i, delta, places[i], want))
+			}
+		}
+	}
+
+	r.prev = scores
+	r.started = true
+
+	for _, err := range errs {
+		fmt.Fprintln(os.Stderr, "RECONCILE:", err)
+	}
+
+	if !r.strict {
+		return nil
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+// replay plays the referee input of a recorded game with a strict
+// reconciler and returns the error it stopped on
+func replay(t *testing.T, input string) error {
+	t.Helper()
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	scanner.Buffer(make([]byte, 1000000), 1000000)
+
+	var playerIdx, nbGames int
+	scanner.Scan()
+	fmt.Sscan(scanner.Text(), &playerIdx)
+	scanner.Scan()
+	fmt.Sscan(scanner.Text(), &nbGames)
+
+	engine := NewEngine(
+		playerIdx,
+		NewHurdling(NewHurdler(), NewHurdler(), NewHurdler()),
+		NewArchery(NewArcher(), NewArcher(), NewArcher()),
+		NewSkating(NewSkater(), NewSkater(), NewSkater()),
+		NewDiving(NewDiver(), NewDiver(), NewDiver()),
+	).WithReconciler(NewReconciler(true))
+
+	return engine.ListenAndServe(scanner)
+}
+
+// arenaGame is the input of a full game refereed by the arena. The arena
+// awards the medals with the rules package the reconciler checks against, so
+// replaying it only checks the bot is consistent with its own model: a bug
+// in the rules can't make it fail. Catching one takes an input recorded from
+// the CodinGame referee.
+func arenaGame(t *testing.T) []string {
+	t.Helper()
+
+	data, err := os.ReadFile("testdata/arena.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+// TestReconcileSelfConsistent checks the score bookkeeping of the
+// reconciler: products, medal timing and placings agree with the rules
+// package on a game it refereed
+func TestReconcileSelfConsistent(t *testing.T) {
+	if err := replay(t, strings.Join(arenaGame(t), "\n")); err != nil {
+		t.Errorf("strict replay of the arena game failed: %v", err)
+	}
+}
+
+func TestReconcileTamperedMedal(t *testing.T) {
+	lines := arenaGame(t)
+
+	// the first scoreInfo line of the last turn, a hurdling gold is added
+	// while no run ends
+	i := len(lines) - 7
+	fields := strings.Fields(lines[i])
+	fields[1] = fmt.Sprint(toInt(fields[1]) + 1)
+	lines[i] = strings.Join(fields, " ")
+
+	if err := replay(t, strings.Join(lines, "\n")); err == nil {
+		t.Error("strict replay accepted a medal the rules don't award")
+	}
+}
@@ -0,0 +1,46 @@
+package rules
+
+// Bound is the absolute value the archery coordinates are clamped to.
+const Bound = 20
+
+// Shoot moves a cursor by the wind in the direction of cmd.
+func Shoot(x, y, wind int, cmd Command) (int, int) {
+	switch cmd {
+	case LEFT:
+		x -= wind
+	case UP:
+		y -= wind
+	case RIGHT:
+		x += wind
+	case DOWN:
+		y += wind
+	}
+
+	return clamp(x, -Bound, Bound), clamp(y, -Bound, Bound)
+}
+
+// Archery is the Turn of the archery, the GPU lists the winds left and the
+// first one is consumed.
+func Archery(winds string, regs [7]int, cmds [3]Command) (string, [7]int) {
+	if winds == EOG || len(winds) == 0 {
+		return winds, regs
+	}
+
+	wind := int(winds[0] - '0')
+	for i, cmd := range cmds {
+		regs[2*i], regs[2*i+1] = Shoot(regs[2*i], regs[2*i+1], wind, cmd)
+	}
+
+	if len(winds) == 1 {
+		return EOG, regs
+	}
+
+	return winds[1:], regs
+}
+
+// ArcheryStanding ranks the archers by their distance to the center, the
+// closest first.
+func ArcheryStanding(regs [7]int, i int) int {
+	x, y := regs[2*i], regs[2*i+1]
+	return -(x*x + y*y)
+}
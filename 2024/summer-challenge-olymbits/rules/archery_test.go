@@ -0,0 +1,29 @@
+package rules
+
+import "testing"
+
+func FuzzArchery(f *testing.F) {
+	f.Add([]byte("9999999999999"), byte(40), byte(0), []byte{0x00, 0x3f, 0x15, 0x2a})
+	f.Add([]byte("0123"), byte(20), byte(20), []byte{0xe4})
+
+	f.Fuzz(func(t *testing.T, winds []byte, x, y byte, moves []byte) {
+		gpu := letters(winds, "0123456789")
+		// the start may be anywhere within the bounds
+		sx, sy := int(x)%(2*Bound+1)-Bound, int(y)%(2*Bound+1)-Bound
+		regs := [7]int{sx, sy, sx, sy, sx, sy, -1}
+
+		for _, b := range moves {
+			if gpu == EOG {
+				return
+			}
+
+			gpu, regs = Archery(gpu, regs, commands(b))
+
+			for i := 0; i < 6; i++ {
+				if regs[i] < -Bound || regs[i] > Bound {
+					t.Fatalf("archer %d out of bounds at %d, %d", i/2, regs[i/2*2], regs[i/2*2+1])
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,34 @@
+package rules
+
+// Dive grows the combo of a diver matching the goal letter and adds it to
+// the points, or resets it otherwise.
+func Dive(goal byte, points, combo int, cmd Command) (int, int) {
+	if len(cmd) == 0 || cmd[0] != goal {
+		return points, 0
+	}
+
+	return points + combo + 1, combo + 1
+}
+
+// Diving is the Turn of the diving, the GPU holds the goal left and its
+// first letter is consumed.
+func Diving(goal string, regs [7]int, cmds [3]Command) (string, [7]int) {
+	if goal == EOG || len(goal) == 0 {
+		return goal, regs
+	}
+
+	for i, cmd := range cmds {
+		regs[i], regs[i+3] = Dive(goal[0], regs[i], regs[i+3], cmd)
+	}
+
+	if len(goal) == 1 {
+		return EOG, regs
+	}
+
+	return goal[1:], regs
+}
+
+// DivingStanding ranks the divers by their points.
+func DivingStanding(regs [7]int, i int) int {
+	return regs[i]
+}
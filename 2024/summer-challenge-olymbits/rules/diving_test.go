@@ -0,0 +1,29 @@
+package rules
+
+import "testing"
+
+func FuzzDiving(f *testing.F) {
+	f.Add([]byte("UUDDLLRR"), []byte{0x00, 0x3f, 0x15, 0x2a, 0x00, 0x00, 0x00, 0x39})
+	f.Add([]byte("U"), []byte{0x00})
+
+	f.Fuzz(func(t *testing.T, goalBytes, moves []byte) {
+		goal := letters(goalBytes, "UDLR")
+		gpu, regs := goal, [7]int{0, 0, 0, 0, 0, 0, -1}
+
+		var combos [3]int
+		for _, b := range moves {
+			if gpu == EOG {
+				return
+			}
+
+			gpu, regs = Diving(gpu, regs, commands(b))
+
+			for i := 0; i < 3; i++ {
+				combos[i] += regs[i+3]
+				if regs[i] != combos[i] {
+					t.Fatalf("diver %d has %d points, the sum of its combos is %d", i, regs[i], combos[i])
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package rules
+
+const (
+	DOT    = '.'
+	HURDLE = '#'
+)
+
+// StunTurns is the number of turns a hurdler hitting a hurdle stays still.
+const StunTurns = 3
+
+var Steps = map[Command]int{
+	LEFT:  1,
+	UP:    2,
+	DOWN:  2,
+	RIGHT: 3,
+}
+
+// Hurdle moves a hurdler space by space, stopping on the first hurdle hit.
+// UP jumps over the next space and only checks the landing one.
+func Hurdle(track string, pos, stun int, cmd Command) (int, int) {
+	if stun > 0 {
+		return pos, stun - 1
+	}
+
+	finish := len(track) - 1
+	for step := 1; step <= Steps[cmd] && pos < finish; step++ {
+		pos++
+		if cmd == UP && step == 1 {
+			continue
+		}
+
+		if track[pos] == HURDLE {
+			return pos, StunTurns
+		}
+	}
+
+	return pos, 0
+}
+
+// Hurdling is the Turn of the hurdle race. The run ends as soon as a
+// hurdler reaches the last space.
+func Hurdling(track string, regs [7]int, cmds [3]Command) (string, [7]int) {
+	if track == EOG {
+		return track, regs
+	}
+
+	done := false
+	for i, cmd := range cmds {
+		regs[i], regs[i+3] = Hurdle(track, regs[i], regs[i+3], cmd)
+		done = done || regs[i] >= len(track)-1
+	}
+
+	if done {
+		return EOG, regs
+	}
+
+	return track, regs
+}
+
+// HurdlingStanding ranks the hurdlers by the spaces they ran.
+func HurdlingStanding(regs [7]int, i int) int {
+	return regs[i]
+}
@@ -0,0 +1,34 @@
+package rules
+
+import "testing"
+
+func FuzzHurdling(f *testing.F) {
+	f.Add([]byte("..#...#..#....."), []byte{0x00, 0x3f, 0x15, 0x2a, 0x39})
+	f.Add([]byte("."), []byte{0xff})
+
+	f.Fuzz(func(t *testing.T, spaces, moves []byte) {
+		track := letters(spaces, string([]byte{DOT, DOT, HURDLE}))
+		gpu, regs := track, [7]int{0, 0, 0, 0, 0, 0, -1}
+
+		for _, b := range moves {
+			if gpu == EOG {
+				return
+			}
+
+			prev := regs
+			gpu, regs = Hurdling(gpu, regs, commands(b))
+
+			for i := 0; i < 3; i++ {
+				if regs[i] < prev[i] {
+					t.Fatalf("hurdler %d went back from %d to %d on %q", i, prev[i], regs[i], track)
+				}
+				if regs[i] > max(len(track)-1, 0) {
+					t.Fatalf("hurdler %d ran past the finish of %q to %d", i, track, regs[i])
+				}
+				if regs[i+3] < 0 || regs[i+3] > StunTurns {
+					t.Fatalf("hurdler %d stunned for %d turns", i, regs[i+3])
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,51 @@
+// Package rules simulates the mini-games of the arcade Olympics on their raw
+// registers, so the bot, the arena and the tuner share one model of the
+// referee.
+package rules
+
+type Command string
+
+const (
+	LEFT  Command = "LEFT"
+	DOWN  Command = "DOWN"
+	RIGHT Command = "RIGHT"
+	UP    Command = "UP"
+)
+
+// Commands lists every action a player may output.
+var Commands = [4]Command{UP, DOWN, LEFT, RIGHT}
+
+// EOG is the GPU of a mini-game on its reset turn.
+const EOG = "GAME_OVER"
+
+// Turn simulates one turn of a mini-game: every player performs its command
+// and the registers of the following turn are returned.
+type Turn func(gpu string, regs [7]int, cmds [3]Command) (string, [7]int)
+
+// Standing rates player i in the registers of a finished run, the higher
+// the better.
+type Standing func(regs [7]int, i int) int
+
+// Places returns the placing of every player at the end of a run. Tied
+// players share the best of their placings, like the medals they win.
+func Places(standing Standing, regs [7]int) [3]int {
+	var places [3]int
+	for i := range places {
+		places[i] = 1
+		for j := range places {
+			if standing(regs, j) > standing(regs, i) {
+				places[i]++
+			}
+		}
+	}
+	return places
+}
+
+func clamp(a, min, max int) int {
+	if a > max {
+		return max
+	} else if a < min {
+		return min
+	}
+	return a
+}
@@ -0,0 +1,43 @@
+package rules
+
+import "testing"
+
+// commands decodes the commands of the three players from the bits of a
+// fuzzed byte
+func commands(b byte) [3]Command {
+	var cmds [3]Command
+	for i := range cmds {
+		cmds[i] = Commands[b>>(2*i)&3]
+	}
+	return cmds
+}
+
+// letters maps fuzzed bytes to a GPU made of the given characters
+func letters(data []byte, chars string) string {
+	gpu := make([]byte, len(data))
+	for i, b := range data {
+		gpu[i] = chars[int(b)%len(chars)]
+	}
+	return string(gpu)
+}
+
+func TestPlaces(t *testing.T) {
+	tests := []struct {
+		name   string
+		regs   [7]int
+		places [3]int
+	}{
+		{"distinct", [7]int{3, 9, 5}, [3]int{3, 1, 2}},
+		{"tied first", [7]int{7, 7, 2}, [3]int{1, 1, 3}},
+		{"tied second", [7]int{8, 1, 1}, [3]int{1, 2, 2}},
+		{"all tied", [7]int{4, 4, 4}, [3]int{1, 1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Places(DivingStanding, tt.regs); got != tt.places {
+				t.Errorf("got places %v, want %v", got, tt.places)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package rules
+
+import "strings"
+
+const (
+	// TrackLength is the number of spaces of the cyclical skating track.
+	TrackLength = 10
+	// MaxRisk is the risk at which a skater falls.
+	MaxRisk = 5
+	// FallTurns is the number of turns a fallen skater stays still.
+	FallTurns = 2
+	// CollisionRisk is the risk added to skaters sharing a space.
+	CollisionRisk = 2
+)
+
+// Ranks holds the spaces travelled and the risk taken by the command at
+// every index of the risk order.
+var Ranks = [4][2]int{
+	{1, -1},
+	{2, 0},
+	{2, 1},
+	{3, 2},
+}
+
+// Rank returns the spaces travelled and the risk taken by cmd for the given
+// risk order, e.g. "ULDR".
+func Rank(order string, cmd Command) (int, int) {
+	if len(cmd) == 0 {
+		return 0, 0
+	}
+
+	i := strings.IndexByte(order, cmd[0])
+	if i < 0 || i >= len(Ranks) {
+		return 0, 0
+	}
+
+	return Ranks[i][0], Ranks[i][1]
+}
+
+// Skating is the Turn of the roller speed skating. Skaters landing on the
+// space of another one raise their risk, reaching MaxRisk makes them fall
+// for FallTurns turns, shown as a negative risk. The next risk order is
+// random, so the current one is kept.
+func Skating(order string, regs [7]int, cmds [3]Command) (string, [7]int) {
+	if order == EOG {
+		return order, regs
+	}
+
+	moved := [3]bool{}
+	for i, cmd := range cmds {
+		if regs[i+3] < 0 {
+			regs[i+3]++
+			continue
+		}
+
+		spaces, risk := Rank(order, cmd)
+		regs[i] += spaces
+		regs[i+3] = max(regs[i+3]+risk, 0)
+		moved[i] = true
+	}
+
+	for i := range cmds {
+		for j := range cmds {
+			if i == j || !moved[i] || regs[i]%TrackLength != regs[j]%TrackLength {
+				continue
+			}
+
+			regs[i+3] += CollisionRisk
+		}
+	}
+
+	for i := range cmds {
+		if regs[i+3] >= MaxRisk {
+			regs[i+3] = -FallTurns
+		}
+	}
+
+	regs[6]--
+	if regs[6] <= 0 {
+		return EOG, regs
+	}
+
+	return order, regs
+}
+
+// SkatingStanding ranks the skaters by the spaces they travelled.
+func SkatingStanding(regs [7]int, i int) int {
+	return regs[i]
+}
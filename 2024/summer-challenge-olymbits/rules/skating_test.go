@@ -0,0 +1,31 @@
+package rules
+
+import "testing"
+
+func FuzzSkating(f *testing.F) {
+	f.Add([]byte("UDLR"), byte(15), []byte{0x00, 0x3f, 0x15, 0x2a, 0x00, 0x00, 0x00})
+	f.Add([]byte("RRRR"), byte(3), []byte{0xff, 0xff, 0xff})
+
+	f.Fuzz(func(t *testing.T, letterBytes []byte, turns byte, moves []byte) {
+		order := letters(letterBytes, "UDLR")
+		gpu, regs := order, [7]int{0, 0, 0, 0, 0, 0, int(turns)%20 + 1}
+
+		for _, b := range moves {
+			if gpu == EOG {
+				return
+			}
+
+			prev := regs
+			gpu, regs = Skating(gpu, regs, commands(b))
+
+			for i := 0; i < 3; i++ {
+				if regs[i] < prev[i] {
+					t.Fatalf("skater %d went back from %d to %d", i, prev[i], regs[i])
+				}
+				if regs[i+3] < -FallTurns || regs[i+3] >= MaxRisk {
+					t.Fatalf("skater %d has risk %d, outside [%d, %d)", i, regs[i+3], -FallTurns, MaxRisk)
+				}
+			}
+		}
+	})
+}
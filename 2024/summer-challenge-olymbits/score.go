@@ -1,6 +1,7 @@
 package main
 
-type Score map[Medal]int
+// Score holds the number of medals earned in a mini-game, indexed by Medal.
+type Score [3]int
 
 func NewScore(gold, silver, bronze int) Score {
 	return Score{
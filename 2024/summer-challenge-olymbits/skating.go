@@ -1,6 +1,10 @@
 package main
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/mendel/codingames/olymbits/rules"
+)
 
 type Skating struct {
 	Race
@@ -23,12 +27,7 @@ func (s Skating) turnsLeft() int {
 	return s.regs[6]
 }
 
-var Ranks = [4][2]int{
-	{1, -1},
-	{2, 0},
-	{2, 1},
-	{3, 2},
-}
+var Ranks = rules.Ranks
 
 func (s Skating) rank(cmd Command) [2]int {
 	i := strings.IndexRune(s.gpu, rune(cmd[0]))
@@ -121,6 +120,18 @@ func (s Skating) Eval(cmd Command, playerIdx int) int {
 	return s.normalize(float64(score), -3, 4)
 }
 
+func (s Skating) Step(cmds [3]Command) (string, [7]int) {
+	return rules.Skating(s.gpu, s.regs, cmds)
+}
+
+func (s Skating) remaining() int {
+	if s.isEOG() {
+		return 0
+	}
+
+	return s.turnsLeft()
+}
+
 type Skater struct {
 	Contestant
 }
@@ -135,3 +146,7 @@ func (s Skater) spaces() int {
 func (s Skater) risk() int {
 	return *s.regs[1]
 }
+
+func (s Skating) standing() rules.Standing {
+	return rules.SkatingStanding
+}
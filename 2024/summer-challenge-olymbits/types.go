@@ -4,23 +4,30 @@ import (
 	"bufio"
 	"fmt"
 	"math"
+	"strconv"
+
+	"github.com/mendel/codingames/olymbits/rules"
 )
 
+// debug enables per-evaluation traces on stderr. It is a constant so the
+// tracing code is compiled out of the hot path when disabled.
+const debug = false
+
 const (
 	ARCHERY  = "ARCHERY"
 	HURDLING = "HURDLING"
 	SKATING  = "SKATING"
 	DIVING   = "DIVING"
 )
-const EOG = "GAME_OVER"
+const EOG = rules.EOG
 
-type Command string
+type Command = rules.Command
 
 const (
-	LEFT  Command = "LEFT"
-	DOWN  Command = "DOWN"
-	RIGHT Command = "RIGHT"
-	UP    Command = "UP"
+	LEFT  = rules.LEFT
+	DOWN  = rules.DOWN
+	RIGHT = rules.RIGHT
+	UP    = rules.UP
 )
 
 type Medal int
@@ -35,8 +42,17 @@ func normalize(n, min, max float64) float64 {
 	return 2*((n-min)/(max-min)) - 1
 }
 
+// UpdateGame loads the registers of a turn into g, recording the final
+// placings while the registers of a run that just ended are still there.
 func UpdateGame(g Game, gpu string, regs [7]int) {
+	ended := gpu == EOG && !g.isEOG() && len(g.Runs()) > 0
+
 	g.Update(gpu, regs)
+
+	// the registers of the reset turn hold the last moves of the run
+	if ended {
+		g.finish(rules.Places(g.standing(), regs))
+	}
 }
 
 func UpdatePlayer(p Player, score Score) {
@@ -52,15 +68,10 @@ func ParseState(scanner *bufio.Scanner) (gpu string, regs [7]int) {
 }
 
 func toInt(str string) int {
-	var result int
-	fmt.Sscan(str, &result)
+	result, _ := strconv.Atoi(str)
 	return result
 }
 
-func checkForHurdle(track string, distance int) bool {
-	return len(track) > distance && track[distance] == HURDLE
-}
-
 func calcScore(remained, move int) int {
 	if remained < move && remained > 0 {
 		return remained
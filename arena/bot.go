@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// ErrTimeout is returned when a bot doesn't answer within its time limit
+var ErrTimeout = errors.New("timeout")
+
+// Bot is a running bot process spoken to over its standard input and output
+// like the CodinGame referee does
+type Bot struct {
+	cmd   *exec.Cmd
+	in    io.WriteCloser
+	out   io.ReadCloser
+	lines chan line
+
+	// done is closed by Close to stop the reader, which closes read once it
+	// returned
+	done chan struct{}
+	read chan struct{}
+}
+
+// StartBot runs command, a path to the bot binary followed by its arguments
+func StartBot(command string) (*Bot, error) {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil, errors.New("empty bot command")
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	b := &Bot{
+		cmd:   cmd,
+		in:    in,
+		out:   out,
+		lines: make(chan line, 16),
+		done:  make(chan struct{}),
+		read:  make(chan struct{}),
+	}
+	go func() {
+		defer close(b.read)
+		defer close(b.lines)
+
+		scanner := bufio.NewScanner(out)
+		scanner.Buffer(make([]byte, 1000000), 1000000)
+		for scanner.Scan() {
+			select {
+			case b.lines <- line{text: scanner.Text(), at: time.Now()}:
+			case <-b.done:
+				return
+			}
+		}
+	}()
+
+	return b, nil
+}
+
+// Send writes lines to the bot's input
+func (b *Bot) Send(lines ...string) error {
+	_, err := io.WriteString(b.in, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// Read returns the next line written by the bot within timeout
+func (b *Bot) Read(timeout time.Duration) (string, error) {
+	return b.ReadBy(time.Now().Add(timeout))
+}
+
+// ReadBy returns the next line written by the bot before deadline, so bots
+// answering the same turn are timed from the same moment. A line is judged
+// by the time it arrived, not the time it is read.
+func (b *Bot) ReadBy(deadline time.Time) (string, error) {
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+
+	select {
+	case l, ok := <-b.lines:
+		return l.by(deadline, ok)
+	case <-timer.C:
+		select {
+		case l, ok := <-b.lines:
+			return l.by(deadline, ok)
+		default:
+			return "", ErrTimeout
+		}
+	}
+}
+
+// line is a line written by a bot and the time it arrived at
+type line struct {
+	text string
+	at   time.Time
+}
+
+// by returns the text of l if it arrived before deadline, ok is false once
+// the bot closed its output
+func (l line) by(deadline time.Time, ok bool) (string, error) {
+	switch {
+	case !ok:
+		return "", fmt.Errorf("bot exited: %w", io.EOF)
+	case l.at.After(deadline):
+		return "", ErrTimeout
+	}
+	return l.text, nil
+}
+
+// Close kills the bot. The reader is stopped before waiting for the
+// process, whether it is blocked on unread lines or on the pipe.
+func (b *Bot) Close() {
+	b.in.Close()
+	b.cmd.Process.Kill()
+
+	close(b.done)
+	b.out.Close()
+	<-b.read
+
+	b.cmd.Wait()
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestBotClose closes bots whose reader is blocked, on lines nobody reads or
+// on a pipe a child process keeps open, and checks the reader is stopped
+func TestBotClose(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+	}{
+		{"unread lines", "yes"},
+		{"pipe held by a child", "/bin/sh -c sleep${IFS}5;true"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot, err := StartBot(tt.command)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// let the reader fill the channel
+			time.Sleep(100 * time.Millisecond)
+
+			closed := make(chan struct{})
+			go func() {
+				bot.Close()
+				close(closed)
+			}()
+
+			select {
+			case <-closed:
+			case <-time.After(2 * time.Second):
+				t.Fatal("Close blocked")
+			}
+
+			select {
+			case <-bot.read:
+			default:
+				t.Error("reader still running after Close")
+			}
+		})
+	}
+}
+
+func TestBotReadBy(t *testing.T) {
+	// the line arrived long before it is read at the deadline
+	bot, err := StartBot("/bin/echo move")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer bot.Close()
+
+	time.Sleep(100 * time.Millisecond)
+	if line, err := bot.ReadBy(time.Now()); err != nil || line != "move" {
+		t.Errorf("got %q, %v, want the line written in time", line, err)
+	}
+
+	silent, err := StartBot("sleep 5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer silent.Close()
+
+	if _, err := silent.ReadBy(time.Now().Add(50 * time.Millisecond)); err != ErrTimeout {
+		t.Errorf("got %v, want %v", err, ErrTimeout)
+	}
+}
@@ -0,0 +1,7 @@
+module github.com/mendel/codingames/arena
+
+go 1.22.3
+
+require github.com/mendel/codingames/olymbits v0.0.0
+
+replace github.com/mendel/codingames/olymbits => ../2024/summer-challenge-olymbits
@@ -0,0 +1,211 @@
+// Arena plays bots against each other over the CodinGame protocol and
+// reports the results of the first one.
+//
+//	arena [flags] "bot-a [args]" "bot-b [args]"
+//	arena [flags] -dir contest rev-a rev-b
+//
+// With -dir, the bots of the contest directory are built at both git
+// revisions and play with the contest's game.
+//
+// Ultimate Tic-Tac-Toe games come in pairs starting from the same seeded
+// opening with the colors swapped. Olymbits games seat bot-a against two
+// copies of bot-b, every seat in turn with the same seeded runs, and count
+// a result of bot-a against each copy.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var (
+	game        = flag.String("game", "uttt", "game played: uttt or olymbits")
+	games       = flag.Int("games", 100, "number of games to play, the maximum with -sprt, 0 for no maximum")
+	firstTurn   = flag.Duration("first-turn", time.Second, "response time allowed on a bot's first turn")
+	turn        = flag.Duration("turn", 0, "response time allowed on the other turns, 0 for the game's limit")
+	concurrency = flag.Int("concurrency", 1, "number of games played in parallel")
+	seed        = flag.Int64("seed", 1, "seed of the first game's starting conditions")
+	openings    = flag.Int("openings", 2, "number of random moves forced at the start of a uttt game, bots must play from validMoves")
+	dir         = flag.String("dir", "", "contest directory, relative to the repository root, whose bots are built at the two git revisions given instead of bot commands")
+
+	sprt  = flag.Bool("sprt", false, "play until an SPRT accepts or rejects bot-a being stronger")
+	elo0  = flag.Float64("elo0", 0, "Elo difference of the SPRT null hypothesis")
+	elo1  = flag.Float64("elo1", 10, "Elo difference of the SPRT alternative hypothesis")
+	alpha = flag.Float64("alpha", 0.05, "SPRT probability of accepting elo1 when elo0 holds")
+	beta  = flag.Float64("beta", 0.05, "SPRT probability of accepting elo0 when elo1 holds")
+)
+
+// turnLimits are the response times of the CodinGame referees
+var turnLimits = map[string]time.Duration{
+	"uttt":     100 * time.Millisecond,
+	"olymbits": 50 * time.Millisecond,
+}
+
+func main() {
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "usage: arena [flags] bot-a bot-b")
+		fmt.Fprintln(os.Stderr, "       arena [flags] -dir contest rev-a rev-b")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+	if *games <= 0 && !*sprt {
+		fmt.Fprintln(os.Stderr, "ERROR: -games must be positive without -sprt")
+		os.Exit(2)
+	}
+
+	bots := [2]string{flag.Arg(0), flag.Arg(1)}
+	if *dir != "" {
+		contest, ok := contests[filepath.Clean(*dir)]
+		if !ok {
+			fmt.Fprintln(os.Stderr, "ERROR: Unknown contest directory:", *dir)
+			os.Exit(2)
+		}
+		*game = contest
+
+		bins, cleanup, err := BuildRevisions(filepath.Clean(*dir), bots)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "ERROR:", err)
+			os.Exit(1)
+		}
+		defer cleanup()
+		bots = bins
+	}
+
+	limit, ok := turnLimits[*game]
+	if !ok {
+		fmt.Fprintln(os.Stderr, "ERROR: Unknown game:", *game)
+		os.Exit(2)
+	}
+	if *turn > 0 {
+		limit = *turn
+	}
+
+	limits := Limits{FirstTurn: *firstTurn, Turn: limit}
+	test := SPRT{Elo0: *elo0, Elo1: *elo1, Alpha: *alpha, Beta: *beta}
+
+	play := playUTTT
+	if *game == "olymbits" {
+		play = playOlymbits
+	}
+
+	tally, verdict := run(func(i int) ([]int, error) {
+		return play(bots, limits, i)
+	}, test)
+
+	fmt.Println(tally)
+	if *sprt {
+		fmt.Println(test.Format(tally))
+		fmt.Println(verdict)
+	}
+}
+
+// run plays the games on concurrency workers, printing the results after
+// every game, until -games are played or the SPRT is decided. A game may
+// count several results, like Tally.Add takes them.
+func run(play func(i int) ([]int, error), test SPRT) (Tally, Verdict) {
+	var (
+		mu      sync.Mutex
+		tally   Tally
+		verdict Verdict
+		next    int
+		wg      sync.WaitGroup
+	)
+
+	for w := 0; w < max(*concurrency, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for {
+				mu.Lock()
+				if verdict != UNDECIDED || (*games > 0 && next >= *games) {
+					mu.Unlock()
+					return
+				}
+				i := next
+				next++
+				mu.Unlock()
+
+				winners, err := play(i)
+
+				mu.Lock()
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "GAME %d: %v\n", i, err)
+				}
+				for _, winner := range winners {
+					tally.Add(winner)
+				}
+				if *sprt {
+					verdict = test.decide(verdict, tally)
+					fmt.Println(tally, test.Format(tally))
+				} else {
+					fmt.Println(tally)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return tally, verdict
+}
+
+// playUTTT plays the game i of a match, bots[0] starting the even games,
+// and returns the winner like Tally.Add takes it
+func playUTTT(bots [2]string, limits Limits, i int) ([]int, error) {
+	opening := Opening(*seed+int64(i/2), *openings)
+
+	if i%2 == 0 {
+		winner, err := PlayUTTT(bots, limits, opening)
+		return []int{winner}, err
+	}
+
+	winner, err := PlayUTTT([2]string{bots[1], bots[0]}, limits, opening)
+	if winner >= 0 {
+		winner = 1 - winner
+	}
+	return []int{winner}, err
+}
+
+// playOlymbits plays the game i of a match, seating bots[0] on seat i%3
+// and bots[1] on the others, and returns a result against every copy of
+// bots[1]
+func playOlymbits(bots [2]string, limits Limits, i int) ([]int, error) {
+	seat := i % 3
+
+	var seats [3]string
+	for s := range seats {
+		seats[s] = bots[1]
+	}
+	seats[seat] = bots[0]
+
+	scores, err := PlayOlymbits(seats, limits, *seed+int64(i/3))
+	return pairwise(scores, seat), err
+}
+
+// pairwise returns the winners, like Tally.Add takes them, of the bot on
+// seat against every other seat. Bots of equal strength score half of the
+// results, where winning against all the others would only happen in a
+// third of the games.
+func pairwise(scores [3]int, seat int) []int {
+	winners := make([]int, 0, len(scores)-1)
+	for s, score := range scores {
+		switch {
+		case s == seat:
+			continue
+		case scores[seat] > score:
+			winners = append(winners, 0)
+		case scores[seat] < score:
+			winners = append(winners, 1)
+		default:
+			winners = append(winners, -1)
+		}
+	}
+	return winners
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPairwise(t *testing.T) {
+	tests := []struct {
+		name    string
+		scores  [3]int
+		seat    int
+		winners []int
+	}{
+		{"first", [3]int{12, 8, 4}, 0, []int{0, 0}},
+		{"second", [3]int{12, 8, 4}, 1, []int{1, 0}},
+		{"last", [3]int{12, 8, 4}, 2, []int{1, 1}},
+		{"tied with one", [3]int{6, 6, 9}, 1, []int{-1, 1}},
+		{"forfeit by another seat", [3]int{0, -1, 0}, 0, []int{0, -1}},
+		{"own forfeit", [3]int{0, 0, -1}, 2, []int{1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pairwise(tt.scores, tt.seat); !reflect.DeepEqual(got, tt.winners) {
+				t.Errorf("got %v, want %v", got, tt.winners)
+			}
+		})
+	}
+}
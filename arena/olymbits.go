@@ -0,0 +1,220 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mendel/codingames/olymbits/rules"
+)
+
+const (
+	// olymbitsTurns is the length of an Olymbits game
+	olymbitsTurns = 100
+	// trackLength is the number of spaces of a hurdle track
+	trackLength = 30
+	// skatingTurns is the length of a skating run
+	skatingTurns = 15
+)
+
+// miniGame is the referee's side of a mini-game: its turn and the ranking
+// of a finished run from the rules package, and the setup of a new run
+type miniGame struct {
+	turn     rules.Turn
+	standing rules.Standing
+	setup    func(rng *rand.Rand) (string, [7]int)
+
+	// reorder draws a new GPU every turn, like the skating risk order
+	reorder bool
+}
+
+var miniGames = [4]miniGame{
+	{
+		turn:     rules.Hurdling,
+		standing: rules.HurdlingStanding,
+		setup: func(rng *rand.Rand) (string, [7]int) {
+			track := []byte(strings.Repeat(".", trackLength))
+			for i := 3; i < trackLength-1; i++ {
+				if track[i-1] != rules.HURDLE && rng.Intn(5) == 0 {
+					track[i] = rules.HURDLE
+				}
+			}
+			return string(track), [7]int{0, 0, 0, 0, 0, 0, -1}
+		},
+	},
+	{
+		turn:     rules.Archery,
+		standing: rules.ArcheryStanding,
+		setup: func(rng *rand.Rand) (string, [7]int) {
+			winds := make([]byte, 12+rng.Intn(4))
+			for i := range winds {
+				winds[i] = byte('0' + rng.Intn(10))
+			}
+			x, y := rng.Intn(2*rules.Bound+1)-rules.Bound, rng.Intn(2*rules.Bound+1)-rules.Bound
+			return string(winds), [7]int{x, y, x, y, x, y, -1}
+		},
+	},
+	{
+		turn:     rules.Skating,
+		standing: rules.SkatingStanding,
+		setup: func(rng *rand.Rand) (string, [7]int) {
+			return riskOrder(rng), [7]int{0, 0, 0, 0, 0, 0, skatingTurns}
+		},
+		reorder: true,
+	},
+	{
+		turn:     rules.Diving,
+		standing: rules.DivingStanding,
+		setup: func(rng *rand.Rand) (string, [7]int) {
+			goal := make([]byte, 12+rng.Intn(4))
+			for i := range goal {
+				goal[i] = rules.Commands[rng.Intn(len(rules.Commands))][0]
+			}
+			return string(goal), [7]int{0, 0, 0, 0, 0, 0, -1}
+		},
+	},
+}
+
+// riskOrder returns a random skating risk order, e.g. "ULDR"
+func riskOrder(rng *rand.Rand) string {
+	order := []byte("UDLR")
+	rng.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+	return string(order)
+}
+
+// medals holds the gold, silver and bronze medals of a player in a
+// mini-game
+type medals [3]int
+
+func (m medals) score() int {
+	return 3*m[0] + m[1]
+}
+
+// PlayOlymbits plays a game between the bot commands, bots[i] controlling
+// the agents of index i, with the runs set up from seed. It returns the
+// final score of every player. A bot that crashes, times out or outputs an
+// unknown command gets a score of -1 and ends the game, the error tells why.
+func PlayOlymbits(bots [3]string, limits Limits, seed int64) ([3]int, error) {
+	rng := rand.New(rand.NewSource(seed))
+
+	var procs [3]*Bot
+	for i, command := range bots {
+		bot, err := StartBot(command)
+		if err != nil {
+			return forfeit(i), fmt.Errorf("bot %d: %w", i, err)
+		}
+		defer bot.Close()
+		procs[i] = bot
+
+		if err := bot.Send(strconv.Itoa(i), strconv.Itoa(len(miniGames))); err != nil {
+			return forfeit(i), fmt.Errorf("bot %d: %w", i, err)
+		}
+	}
+
+	var gpus [4]string
+	var regs [4][7]int
+	for g, game := range miniGames {
+		gpus[g], regs[g] = game.setup(rng)
+	}
+	var won [3][4]medals
+
+	for turn := 0; turn < olymbitsTurns; turn++ {
+		input := make([]string, 0, len(procs)+len(miniGames))
+		for i := range procs {
+			input = append(input, scoreInfo(won[i]))
+		}
+		for g := range miniGames {
+			input = append(input, fmt.Sprintf("%s %d %d %d %d %d %d %d", gpus[g], regs[g][0], regs[g][1], regs[g][2], regs[g][3], regs[g][4], regs[g][5], regs[g][6]))
+		}
+
+		limit := limits.Turn
+		if turn == 0 {
+			limit = limits.FirstTurn
+		}
+
+		var cmds [3]rules.Command
+		for i, bot := range procs {
+			if err := bot.Send(input...); err != nil {
+				return forfeit(i), fmt.Errorf("bot %d: %w", i, err)
+			}
+		}
+		// every bot answers against the same deadline, the first ones read
+		// don't delay the clock of the others
+		deadline := time.Now().Add(limit)
+		for i, bot := range procs {
+			line, err := bot.ReadBy(deadline)
+			if err != nil {
+				return forfeit(i), fmt.Errorf("bot %d, turn %d: %w", i, turn+1, err)
+			}
+
+			fields := strings.Fields(line)
+			if len(fields) == 0 || !isCommand(rules.Command(fields[0])) {
+				return forfeit(i), fmt.Errorf("bot %d, turn %d: invalid output %q", i, turn+1, line)
+			}
+			cmds[i] = rules.Command(fields[0])
+		}
+
+		for g, game := range miniGames {
+			if gpus[g] == rules.EOG {
+				gpus[g], regs[g] = game.setup(rng)
+				continue
+			}
+
+			gpus[g], regs[g] = game.turn(gpus[g], regs[g], cmds)
+			if gpus[g] != rules.EOG {
+				if game.reorder {
+					gpus[g] = riskOrder(rng)
+				}
+				continue
+			}
+
+			for i, place := range rules.Places(game.standing, regs[g]) {
+				won[i][g][place-1]++
+			}
+		}
+	}
+
+	var scores [3]int
+	for i := range scores {
+		scores[i] = total(won[i])
+	}
+	return scores, nil
+}
+
+// forfeit returns the scores of a game lost by bot i
+func forfeit(i int) [3]int {
+	scores := [3]int{}
+	scores[i] = -1
+	return scores
+}
+
+func isCommand(cmd rules.Command) bool {
+	for _, c := range rules.Commands {
+		if c == cmd {
+			return true
+		}
+	}
+	return false
+}
+
+func total(won [4]medals) int {
+	product := 1
+	for _, m := range won {
+		product *= m.score()
+	}
+	return product
+}
+
+// scoreInfo formats the medals of a player like the referee does: the final
+// score followed by the gold, silver and bronze medals of every mini-game
+func scoreInfo(won [4]medals) string {
+	fields := []string{strconv.Itoa(total(won))}
+	for _, m := range won {
+		for _, n := range m {
+			fields = append(fields, strconv.Itoa(n))
+		}
+	}
+	return strings.Join(fields, " ")
+}
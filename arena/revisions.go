@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// contests maps the contest directories to the game their bots play
+var contests = map[string]string{
+	"tictactoe":                      "uttt",
+	"2024/summer-challenge-olymbits": "olymbits",
+}
+
+// git runs git in dir and returns its trimmed output
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// BuildRevisions checks out every revision of the repository holding the
+// working directory into a git worktree of a temporary directory and builds
+// the bot of the contest directory dir there. It returns the paths of the
+// binaries and a function removing the worktrees and the binaries.
+func BuildRevisions(dir string, revs [2]string) ([2]string, func(), error) {
+	var bins [2]string
+
+	root, err := git(".", "rev-parse", "--show-toplevel")
+	if err != nil {
+		return bins, nil, err
+	}
+
+	tmp, err := os.MkdirTemp("", "arena-")
+	if err != nil {
+		return bins, nil, err
+	}
+
+	var worktrees []string
+	cleanup := func() {
+		for _, wt := range worktrees {
+			git(root, "worktree", "remove", "--force", wt)
+		}
+		os.RemoveAll(tmp)
+	}
+
+	for i, rev := range revs {
+		wt := filepath.Join(tmp, fmt.Sprint("rev", i))
+		if _, err := git(root, "worktree", "add", "--detach", wt, rev); err != nil {
+			cleanup()
+			return bins, nil, err
+		}
+		worktrees = append(worktrees, wt)
+
+		bins[i] = filepath.Join(tmp, fmt.Sprint("bot", i))
+		build := exec.Command("go", "build", "-o", bins[i], ".")
+		build.Dir = filepath.Join(wt, dir)
+		build.Stderr = os.Stderr
+		if err := build.Run(); err != nil {
+			cleanup()
+			return bins, nil, fmt.Errorf("build %s at %s: %w", dir, rev, err)
+		}
+
+		commit, _ := git(wt, "rev-parse", "--short", "HEAD")
+		fmt.Fprintf(os.Stderr, "BUILD: bot %d is %s at %s (%s)\n", i, dir, rev, commit)
+	}
+
+	return bins, cleanup, nil
+}
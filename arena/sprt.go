@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// SPRT is a sequential probability ratio test of H0, the first bot is Elo0
+// stronger than the second, against H1, it is Elo1 stronger. Alpha and Beta
+// are the probabilities of accepting H1 when H0 holds and H0 when H1 holds.
+type SPRT struct {
+	Elo0, Elo1  float64
+	Alpha, Beta float64
+}
+
+// Verdict is the outcome of an SPRT
+type Verdict int
+
+const (
+	UNDECIDED Verdict = iota
+	H0
+	H1
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case H0:
+		return "H0 accepted"
+	case H1:
+		return "H1 accepted"
+	}
+	return "undecided"
+}
+
+// Bounds returns the log-likelihood ratios at which H0 and H1 are accepted
+func (s SPRT) Bounds() (lower, upper float64) {
+	return math.Log(s.Beta / (1 - s.Alpha)), math.Log((1 - s.Beta) / s.Alpha)
+}
+
+// LLR returns the log-likelihood ratio of H1 over H0 given the results,
+// approximating the scores of the games by a normal distribution. The
+// variance counts one more win and one more loss, so that a run of
+// identical results doesn't make it zero.
+func (s SPRT) LLR(t Tally) float64 {
+	if t.Games() == 0 {
+		return 0
+	}
+
+	score, _ := t.Score()
+	prior := Tally{Wins: t.Wins + 1, Draws: t.Draws, Losses: t.Losses + 1}
+	variance := prior.variance(score)
+
+	s0, s1 := Expected(s.Elo0), Expected(s.Elo1)
+	return float64(t.Games()) * (s1 - s0) * (2*score - s0 - s1) / (2 * variance)
+}
+
+// Test returns the log-likelihood ratio of the results and the hypothesis
+// accepted once it crosses a bound
+func (s SPRT) Test(t Tally) (float64, Verdict) {
+	llr := s.LLR(t)
+	lower, upper := s.Bounds()
+
+	switch {
+	case llr <= lower:
+		return llr, H0
+	case llr >= upper:
+		return llr, H1
+	}
+	return llr, UNDECIDED
+}
+
+// decide returns the verdict of the results until one is accepted, games
+// still running when the test is decided are counted but can't change it
+func (s SPRT) decide(v Verdict, t Tally) Verdict {
+	if v != UNDECIDED {
+		return v
+	}
+
+	_, v = s.Test(t)
+	return v
+}
+
+func (s SPRT) Format(t Tally) string {
+	llr, _ := s.Test(t)
+	lower, upper := s.Bounds()
+	return fmt.Sprintf("LLR %.2f [%.2f, %.2f] elo0 %g elo1 %g", llr, lower, upper, s.Elo0, s.Elo1)
+}
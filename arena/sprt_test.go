@@ -0,0 +1,56 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+var elo10 = SPRT{Elo0: 0, Elo1: 10, Alpha: 0.05, Beta: 0.05}
+
+func TestSPRTBounds(t *testing.T) {
+	lower, upper := elo10.Bounds()
+	if math.Abs(lower+2.9444) > 1e-4 || math.Abs(upper-2.9444) > 1e-4 {
+		t.Errorf("got [%f, %f], want [-2.9444, 2.9444]", lower, upper)
+	}
+}
+
+func TestSPRTTest(t *testing.T) {
+	tests := []struct {
+		name    string
+		tally   Tally
+		llr     float64
+		verdict Verdict
+	}{
+		{"no games", Tally{}, 0, UNDECIDED},
+		{"even", Tally{Wins: 10, Losses: 10}, -0.0083, UNDECIDED},
+		{"ahead", Tally{Wins: 60, Draws: 20, Losses: 40}, 0.6498, UNDECIDED},
+		{"stronger", Tally{Wins: 600, Losses: 400}, 5.5625, H1},
+		{"weaker", Tally{Wins: 400, Losses: 600}, -6.4248, H0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			llr, verdict := elo10.Test(tt.tally)
+			if math.Abs(llr-tt.llr) > 1e-4 || verdict != tt.verdict {
+				t.Errorf("got %.4f %v, want %.4f %v", llr, verdict, tt.llr, tt.verdict)
+			}
+		})
+	}
+}
+
+// TestSPRTDecideKeepsFirstVerdict plays on past an accepted H1 until the
+// results alone would accept H0
+func TestSPRTDecideKeepsFirstVerdict(t *testing.T) {
+	verdict := elo10.decide(UNDECIDED, Tally{Wins: 600, Losses: 400})
+	if verdict != H1 {
+		t.Fatalf("got %v, want %v", verdict, H1)
+	}
+
+	later := Tally{Wins: 600, Losses: 1400}
+	if _, v := elo10.Test(later); v != H0 {
+		t.Fatalf("later results: got %v, want %v", v, H0)
+	}
+	if got := elo10.decide(verdict, later); got != H1 {
+		t.Errorf("got %v, want the first verdict %v", got, H1)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// Tally counts the results of a match from the perspective of the first bot
+type Tally struct {
+	Wins, Draws, Losses int
+}
+
+// Add counts a game won by winner, the index of a bot or -1 on a draw
+func (t *Tally) Add(winner int) {
+	switch winner {
+	case 0:
+		t.Wins++
+	case 1:
+		t.Losses++
+	default:
+		t.Draws++
+	}
+}
+
+func (t Tally) Games() int {
+	return t.Wins + t.Draws + t.Losses
+}
+
+// Score returns the average score of the first bot, a draw counting half a
+// win, and the margin of its 95% confidence interval
+func (t Tally) Score() (score, margin float64) {
+	n := float64(t.Games())
+	if n == 0 {
+		return 0.5, 0.5
+	}
+
+	score = (float64(t.Wins) + float64(t.Draws)/2) / n
+	return score, 1.96 * math.Sqrt(t.variance(score)/n)
+}
+
+// variance returns the variance of the score of a single game
+func (t Tally) variance(score float64) float64 {
+	return (float64(t.Wins)*math.Pow(1-score, 2) +
+		float64(t.Draws)*math.Pow(0.5-score, 2) +
+		float64(t.Losses)*math.Pow(score, 2)) / float64(t.Games())
+}
+
+// Elo returns the Elo difference matching score, an expected score between
+// 0 and 1
+func Elo(score float64) float64 {
+	score = math.Min(math.Max(score, 1e-6), 1-1e-6)
+	return -400 * math.Log10(1/score-1)
+}
+
+// Expected returns the expected score of a player stronger by elo
+func Expected(elo float64) float64 {
+	return 1 / (1 + math.Pow(10, -elo/400))
+}
+
+func (t Tally) String() string {
+	score, margin := t.Score()
+	elo := Elo(score)
+	eloMargin := (Elo(score+margin) - Elo(score-margin)) / 2
+
+	return fmt.Sprintf("results %d: wins %d, draws %d, losses %d, score %.1f%% ± %.1f%%, elo %+.0f ± %.0f",
+		t.Games(), t.Wins, t.Draws, t.Losses, 100*score, 100*margin, elo, eloMargin)
+}
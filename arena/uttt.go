@@ -0,0 +1,224 @@
+package main
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const full uint16 = 1<<9 - 1
+
+// lines holds the masks of the rows, columns and diagonals of a 3×3 board
+var lines = [8]uint16{
+	0b000_000_111, 0b000_111_000, 0b111_000_000,
+	0b001_001_001, 0b010_010_010, 0b100_100_100,
+	0b100_010_001, 0b001_010_100,
+}
+
+func isLine(mask uint16) bool {
+	for _, line := range lines {
+		if mask&line == line {
+			return true
+		}
+	}
+	return false
+}
+
+// uttt is the referee's Ultimate Tic-Tac-Toe board, written apart from the
+// bot's own rules so a bug in them shows up as an invalid move
+type uttt struct {
+	cells [2][9]uint16
+	macro [2]uint16
+	drawn uint16
+
+	// target is the sub-board the next move must be played in, -1 when the
+	// choice is free
+	target int
+}
+
+type move struct {
+	row, col int
+}
+
+func (m move) String() string {
+	return fmt.Sprint(m.row, m.col)
+}
+
+func (u *uttt) closed() uint16 {
+	return u.macro[0] | u.macro[1] | u.drawn
+}
+
+// moves returns the legal moves sorted by row and column, like the
+// CodinGame referee sends them
+func (u *uttt) moves() []move {
+	var moves []move
+	for row := 0; row < 9; row++ {
+		for col := 0; col < 9; col++ {
+			sub, cell := row/3*3+col/3, row%3*3+col%3
+			if u.closed()&(1<<sub) != 0 || (u.target >= 0 && u.target != sub) {
+				continue
+			}
+			if (u.cells[0][sub]|u.cells[1][sub])&(1<<cell) == 0 {
+				moves = append(moves, move{row, col})
+			}
+		}
+	}
+	return moves
+}
+
+// play puts a cell of player p, 0 or 1, at m
+func (u *uttt) play(p int, m move) {
+	sub, cell := m.row/3*3+m.col/3, m.row%3*3+m.col%3
+	u.cells[p][sub] |= 1 << cell
+
+	if isLine(u.cells[p][sub]) {
+		u.macro[p] |= 1 << sub
+	} else if u.cells[0][sub]|u.cells[1][sub] == full {
+		u.drawn |= 1 << sub
+	}
+
+	u.target = cell
+	if u.closed()&(1<<cell) != 0 {
+		u.target = -1
+	}
+}
+
+// winner returns the player who won, 0 or 1, -1 on a draw, and false while
+// the game goes on. When every sub-board is closed without a line the
+// player who won the most sub-boards wins.
+func (u *uttt) winner() (int, bool) {
+	for p := range u.macro {
+		if isLine(u.macro[p]) {
+			return p, true
+		}
+	}
+
+	if u.closed() != full {
+		return 0, false
+	}
+
+	switch a, b := bits.OnesCount16(u.macro[0]), bits.OnesCount16(u.macro[1]); {
+	case a > b:
+		return 0, true
+	case a < b:
+		return 1, true
+	}
+	return -1, true
+}
+
+// parseMove reads the row and column leading a bot's output line, the rest
+// of the line is a message
+func parseMove(line string) (move, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return move{}, fmt.Errorf("invalid output %q", line)
+	}
+
+	row, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return move{}, fmt.Errorf("invalid output %q", line)
+	}
+	col, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return move{}, fmt.Errorf("invalid output %q", line)
+	}
+	return move{row, col}, nil
+}
+
+// Limits are the response times allowed to the bots
+type Limits struct {
+	FirstTurn time.Duration
+	Turn      time.Duration
+}
+
+// Opening returns n random moves played from the empty board with seed, so
+// both colors of a pair of games can start from the same position
+func Opening(seed int64, n int) []move {
+	rng := rand.New(rand.NewSource(seed))
+	board := uttt{target: -1}
+
+	var opening []move
+	for p := 0; len(opening) < n; p = 1 - p {
+		if _, over := board.winner(); over {
+			break
+		}
+
+		moves := board.moves()
+		m := moves[rng.Intn(len(moves))]
+		board.play(p, m)
+		opening = append(opening, m)
+	}
+	return opening
+}
+
+// PlayUTTT plays a game between the bot commands, bots[0] moving first. The
+// moves of opening are forced by sending them as the only valid action. It
+// returns the index of the winner, -1 on a draw. A bot that crashes, times
+// out or plays an invalid move loses, the error tells why.
+func PlayUTTT(bots [2]string, limits Limits, opening []move) (int, error) {
+	var procs [2]*Bot
+	for i, command := range bots {
+		bot, err := StartBot(command)
+		if err != nil {
+			return 1 - i, fmt.Errorf("bot %d: %w", i, err)
+		}
+		defer bot.Close()
+		procs[i] = bot
+	}
+
+	board := uttt{target: -1}
+	last := move{-1, -1}
+	turns := [2]int{}
+
+	for p, ply := 0, 0; ; p, ply = 1-p, ply+1 {
+		if winner, over := board.winner(); over {
+			return winner, nil
+		}
+
+		moves := board.moves()
+		if ply < len(opening) {
+			moves = []move{opening[ply]}
+		}
+		input := []string{last.String(), strconv.Itoa(len(moves))}
+		for _, m := range moves {
+			input = append(input, m.String())
+		}
+
+		limit := limits.Turn
+		if turns[p] == 0 {
+			limit = limits.FirstTurn
+		}
+		turns[p]++
+
+		if err := procs[p].Send(input...); err != nil {
+			return 1 - p, fmt.Errorf("bot %d: %w", p, err)
+		}
+		line, err := procs[p].Read(limit)
+		if err != nil {
+			return 1 - p, fmt.Errorf("bot %d, turn %d: %w", p, turns[p], err)
+		}
+
+		m, err := parseMove(line)
+		if err != nil {
+			return 1 - p, fmt.Errorf("bot %d, turn %d: %w", p, turns[p], err)
+		}
+		if !contains(moves, m) {
+			return 1 - p, fmt.Errorf("bot %d, turn %d: invalid move %v", p, turns[p], m)
+		}
+
+		board.play(p, m)
+		last = m
+	}
+}
+
+func contains(moves []move, m move) bool {
+	for _, legal := range moves {
+		if legal == m {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestUTTTMoves(t *testing.T) {
+	var u uttt
+	u.target = -1
+	if got := len(u.moves()); got != 81 {
+		t.Fatalf("empty board: got %d moves, want 81", got)
+	}
+
+	// the center cell of the center sub-board sends the opponent back to it
+	u.play(0, move{4, 4})
+	want := []move{{3, 3}, {3, 4}, {3, 5}, {4, 3}, {4, 5}, {5, 3}, {5, 4}, {5, 5}}
+	if got := u.moves(); !reflect.DeepEqual(got, want) {
+		t.Errorf("sent to the center: got %v, want %v", got, want)
+	}
+
+	// a move sending the opponent to a closed sub-board frees the choice,
+	// and the closed sub-board has no moves left
+	u = uttt{target: -1}
+	u.play(0, move{0, 0})
+	u.play(0, move{0, 1})
+	u.play(0, move{0, 2})
+	if u.macro[0] != 1 {
+		t.Fatalf("row of the top left sub-board: got macro %b, want 1", u.macro[0])
+	}
+	u.play(1, move{3, 0})
+	if u.target != -1 {
+		t.Errorf("sent to a won sub-board: got target %d, want -1", u.target)
+	}
+	moves := u.moves()
+	if len(moves) != 81-9-1 {
+		t.Errorf("free choice: got %d moves, want %d", len(moves), 81-9-1)
+	}
+	for _, m := range moves {
+		if m.row < 3 && m.col < 3 {
+			t.Errorf("free choice: got %v in the won sub-board", m)
+		}
+	}
+}
+
+func TestUTTTPlayDrawsSubBoard(t *testing.T) {
+	u := uttt{target: -1}
+	// X O X / X O O / O X X fills the top left sub-board without a line
+	for i, p := range []int{0, 1, 0, 0, 1, 1, 1, 0, 0} {
+		u.play(p, move{i / 3, i % 3})
+	}
+	if u.drawn != 1 || u.macro != [2]uint16{} {
+		t.Errorf("got drawn %b and macros %b, want drawn 1 and no macros", u.drawn, u.macro)
+	}
+}
+
+func TestUTTTWinner(t *testing.T) {
+	// sub-boards are bits in row order
+	const (
+		x = 0b110_001_101 // X O X / X O O / O X X
+		o = 0b001_110_010
+	)
+
+	tests := []struct {
+		name   string
+		macro  [2]uint16
+		drawn  uint16
+		winner int
+		over   bool
+	}{
+		{"empty", [2]uint16{}, 0, 0, false},
+		{"row", [2]uint16{0b000_000_111, 0b000_011_000}, 0, 0, true},
+		{"anti-diagonal", [2]uint16{0b000_000_011, 0b001_010_100}, 0, 1, true},
+		{"open sub-board", [2]uint16{x &^ (1 << 8), o}, 0, 0, false},
+		{"first has the most sub-boards", [2]uint16{x, o}, 0, 0, true},
+		{"second has the most sub-boards", [2]uint16{x &^ (1<<8 | 1<<7), o}, 1<<8 | 1<<7, 1, true},
+		{"as many sub-boards", [2]uint16{x &^ (1 << 8), o}, 1 << 8, -1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := uttt{macro: tt.macro, drawn: tt.drawn}
+			winner, over := u.winner()
+			if over != tt.over || (over && winner != tt.winner) {
+				t.Errorf("got %d %v, want %d %v", winner, over, tt.winner, tt.over)
+			}
+		})
+	}
+}
+
+func TestTallyScore(t *testing.T) {
+	tests := []struct {
+		name          string
+		tally         Tally
+		score, margin float64
+	}{
+		{"no games", Tally{}, 0.5, 0.5},
+		{"all wins", Tally{Wins: 5}, 1, 0},
+		{"all draws", Tally{Draws: 4}, 0.5, 0},
+		{"mixed", Tally{Wins: 6, Draws: 2, Losses: 2}, 0.7, 0.247923},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score, margin := tt.tally.Score()
+			if math.Abs(score-tt.score) > 1e-6 || math.Abs(margin-tt.margin) > 1e-6 {
+				t.Errorf("got %f ± %f, want %f ± %f", score, margin, tt.score, tt.margin)
+			}
+		})
+	}
+}
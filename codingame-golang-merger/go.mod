@@ -0,0 +1,3 @@
+module github.com/mendel/codingames/merger
+
+go 1.22.3
@@ -0,0 +1,39 @@
+// Command codingame-golang-merger writes the single-file submission CodinGame
+// takes for a bot split into several packages, e.g. from this directory:
+//
+//	go run . -o /tmp/uttt.go ../tictactoe
+//	go run . -o /tmp/olymbits.go ../2024/summer-challenge-olymbits
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+var output = flag.String("o", "", "file the submission is written to, standard output when empty")
+
+func main() {
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: codingame-golang-merger [-o file] <main package directory>")
+		os.Exit(2)
+	}
+
+	src, err := Merge(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR:", err)
+		os.Exit(1)
+	}
+
+	if *output == "" {
+		os.Stdout.Write(src)
+		return
+	}
+
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// listed is a package as printed by go list -json
+type listed struct {
+	ImportPath string
+	Name       string
+	Dir        string
+	GoFiles    []string
+	Standard   bool
+}
+
+// list returns the packages the main package in dir is built from, the
+// standard library left out, dependencies first
+func list(dir string) ([]listed, error) {
+	cmd := exec.Command("go", "list", "-deps", "-json", ".")
+	cmd.Dir = dir
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go list in %s: %w", dir, err)
+	}
+
+	var pkgs []listed
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for dec.More() {
+		var pkg listed
+		if err := dec.Decode(&pkg); err != nil {
+			return nil, err
+		}
+		if !pkg.Standard {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+
+	if len(pkgs) == 0 || pkgs[len(pkgs)-1].Name != "main" {
+		return nil, fmt.Errorf("%s is not a main package", dir)
+	}
+	return pkgs, nil
+}
+
+// localImporter imports the packages already checked by Merge, and the
+// standard library from its export data
+type localImporter struct {
+	local map[string]*types.Package
+	std   types.Importer
+}
+
+func (i localImporter) Import(path string) (*types.Package, error) {
+	if pkg, ok := i.local[path]; ok {
+		return pkg, nil
+	}
+	return i.std.Import(path)
+}
+
+// edit replaces the bytes of a file between two offsets
+type edit struct {
+	start, end int
+	text       string
+}
+
+// Merge returns a single file holding the main package in dir and every
+// package it imports outside of the standard library, like CodinGame takes
+// a submission. The package-level names of the imported packages are
+// prefixed with their package name, so they can't collide with each other
+// or with the main package, and their qualified uses are rewritten to the
+// prefixed names.
+func Merge(dir string) ([]byte, error) {
+	pkgs, err := list(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixes := make(map[string]string, len(pkgs))
+	for _, pkg := range pkgs[:len(pkgs)-1] {
+		prefixes[pkg.ImportPath] = pkg.Name + "_"
+	}
+
+	fset := token.NewFileSet()
+	imp := localImporter{local: map[string]*types.Package{}, std: importer.Default()}
+	imports := map[string]bool{}
+	var body bytes.Buffer
+
+	for _, pkg := range pkgs {
+		var files []*ast.File
+		var srcs [][]byte
+		for _, name := range pkg.GoFiles {
+			src, err := os.ReadFile(filepath.Join(pkg.Dir, name))
+			if err != nil {
+				return nil, err
+			}
+
+			file, err := parser.ParseFile(fset, filepath.Join(pkg.Dir, name), src, parser.ParseComments)
+			if err != nil {
+				return nil, err
+			}
+			files, srcs = append(files, file), append(srcs, src)
+		}
+
+		info := &types.Info{
+			Defs: map[*ast.Ident]types.Object{},
+			Uses: map[*ast.Ident]types.Object{},
+		}
+		conf := types.Config{Importer: imp}
+		checked, err := conf.Check(pkg.ImportPath, fset, files, info)
+		if err != nil {
+			return nil, err
+		}
+		imp.local[pkg.ImportPath] = checked
+
+		for i, file := range files {
+			for _, spec := range file.Imports {
+				path, _ := strconv.Unquote(spec.Path.Value)
+				if _, ok := imp.local[path]; ok {
+					continue
+				}
+
+				line := spec.Path.Value
+				if spec.Name != nil {
+					line = spec.Name.Name + " " + line
+				}
+				imports[line] = true
+			}
+
+			start := fset.Position(file.Name.End()).Offset
+			for _, decl := range file.Decls {
+				if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+					start = fset.Position(gen.End()).Offset
+				}
+			}
+
+			edits := renames(fset, file, info, checked, prefixes)
+			fmt.Fprintf(&body, "\n// %s\n", filepath.Join(pkg.ImportPath, pkg.GoFiles[i]))
+			body.Write(apply(srcs[i], start, edits))
+			body.WriteString("\n")
+		}
+	}
+
+	lines := make([]string, 0, len(imports))
+	for line := range imports {
+		lines = append(lines, line)
+	}
+	sort.Strings(lines)
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by codingame-golang-merger from %s. DO NOT EDIT.\n\npackage main\n\n", pkgs[len(pkgs)-1].ImportPath)
+	if len(lines) > 0 {
+		out.WriteString("import (\n")
+		for _, line := range lines {
+			fmt.Fprintf(&out, "\t%s\n", line)
+		}
+		out.WriteString(")\n")
+	}
+	out.Write(body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// a standard import may still collide with a name of another package
+	merged, err := parser.ParseFile(fset, "main.go", src, 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := (&types.Config{Importer: imp.std}).Check("main", fset, []*ast.File{merged}, nil); err != nil {
+		return nil, fmt.Errorf("merged source doesn't compile: %w", err)
+	}
+
+	return src, nil
+}
+
+// renames returns the edits of file prefixing the package-level names of
+// the imported packages: qualified uses lose their qualifier, and the
+// declarations and uses inside an imported package get its prefix
+func renames(fset *token.FileSet, file *ast.File, info *types.Info, pkg *types.Package, prefixes map[string]string) []edit {
+	var edits []edit
+	replace := func(from, to token.Pos, text string) {
+		edits = append(edits, edit{fset.Position(from).Offset, fset.Position(to).Offset, text})
+	}
+
+	own := prefixes[pkg.Path()]
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			x, ok := n.X.(*ast.Ident)
+			if !ok {
+				return true
+			}
+			name, ok := info.Uses[x].(*types.PkgName)
+			if !ok {
+				return true
+			}
+			if prefix, ok := prefixes[name.Imported().Path()]; ok {
+				replace(x.Pos(), n.Sel.End(), prefix+n.Sel.Name)
+			}
+			return false
+
+		case *ast.Ident:
+			if own == "" || n.Name == "_" || n.Name == "init" {
+				return true
+			}
+
+			obj := info.Defs[n]
+			if obj == nil {
+				obj = info.Uses[n]
+			}
+			if obj != nil && obj.Pkg() == pkg && obj.Parent() == pkg.Scope() {
+				replace(n.Pos(), n.End(), own+n.Name)
+			}
+		}
+		return true
+	})
+
+	return edits
+}
+
+// apply returns src from start on with the edits made
+func apply(src []byte, start int, edits []edit) []byte {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+
+	var out bytes.Buffer
+	at := start
+	for _, e := range edits {
+		if e.start < at {
+			continue
+		}
+		out.Write(src[at:e.start])
+		out.WriteString(e.text)
+		at = e.end
+	}
+	out.Write(src[at:])
+
+	return out.Bytes()
+}
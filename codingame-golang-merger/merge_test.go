@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestMergeBuilds merges the bots of the repository and builds the
+// submissions on their own, like CodinGame does
+func TestMergeBuilds(t *testing.T) {
+	for _, dir := range []string{"../tictactoe", "../2024/summer-challenge-olymbits"} {
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			src, err := Merge(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			out := t.TempDir()
+			if err := os.WriteFile(filepath.Join(out, "main.go"), src, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(out, "go.mod"), []byte("module submission\n\ngo 1.22.3\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, args := range [][]string{{"build", "-o", os.DevNull, "."}, {"vet", "."}} {
+				cmd := exec.Command("go", args...)
+				cmd.Dir = out
+				if output, err := cmd.CombinedOutput(); err != nil {
+					t.Fatalf("go %s: %v\n%s", args[0], err, output)
+				}
+			}
+		})
+	}
+}
+
+// TestMergedUpToDate checks the committed Olymbits submission was merged
+// from the current sources
+func TestMergedUpToDate(t *testing.T) {
+	const dir, submission = "../2024/summer-challenge-olymbits", "../2024/summer-challenge-olymbits/merged/merged.go"
+
+	src, err := Merge(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	committed, err := os.ReadFile(submission)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(src, committed) {
+		t.Errorf("%s is stale, run: go run . -o %s %s", submission, submission, dir)
+	}
+}
@@ -0,0 +1,469 @@
+// Package mcts implements a Monte Carlo tree search over any turn-based game
+// state. The selection, expansion, rollout and backpropagation policies are
+// pluggable, the workers search in parallel and proven results are
+// propagated like in MCTS-Solver.
+package mcts
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"time"
+)
+
+type Player int
+
+type Result float64
+
+type State[A comparable] interface {
+	Clone() State[A]
+	Actions() []A
+	IsEOG() bool
+	Player() Player
+	Exec(p Player, a A)
+	Eval(p Player) Result
+}
+
+// Parallelism is the way the workers split the search
+type Parallelism int
+
+const (
+	// TREE workers share a single tree
+	TREE Parallelism = iota
+	// ROOT workers search their own tree from a clone of the root state,
+	// the statistics of the root children are merged at the deadline
+	ROOT
+)
+
+// Config tunes the search
+type Config[S State[A], A comparable] struct {
+	// Workers is the number of goroutines searching
+	Workers int
+	// Parallelism selects how the workers split the search
+	Parallelism Parallelism
+	// VirtualLoss is the number of losses a worker adds to the nodes of its
+	// path until its simulation is backpropagated
+	VirtualLoss int
+	// RaveEquivalence is the number of visits at which a child's own value
+	// and its RAVE value weigh the same, 0 disables RAVE
+	RaveEquivalence float64
+	// Transpositions is the log2 of the size of the table through which the
+	// nodes of the same position share their statistics, 0 disables it. It
+	// needs states implementing Hasher and only applies to TREE parallelism.
+	Transpositions int
+	// Log receives a line of statistics after every search, nil discards
+	// them
+	Log io.Writer
+
+	Select   SelectPolicy[S, A]
+	Expand   ExpandPolicy[S, A]
+	Rollout  RolloutPolicy[S, A]
+	Backprop BackpropPolicy
+}
+
+// DefaultExploration is the exploration constant of the default UCT
+const DefaultExploration = 1.0
+
+// DefaultConfig searches a shared tree with 4 workers, UCT selection, random
+// rollouts and negamax rewards
+func DefaultConfig[S State[A], A comparable]() Config[S, A] {
+	return Config[S, A]{
+		Workers:     4,
+		VirtualLoss: 1,
+		Expand:      AllActions[S, A],
+		Rollout:     Random[S, A],
+		Backprop:    Negamax,
+	}
+}
+
+// MCTS keeps the search tree between turns, so the statistics gathered for
+// the position reached after the played moves are reused
+type MCTS[S State[A], A comparable] struct {
+	root   *Node[S, A]
+	config Config[S, A]
+
+	// table maps the hash of a position to the statistics of its nodes
+	table *Table[*stats]
+}
+
+func New[S State[A], A comparable](state S, config Config[S, A]) *MCTS[S, A] {
+	if config.Select == nil {
+		config.Select = UCT[S, A](DefaultExploration, config.RaveEquivalence)
+	}
+	if config.Expand == nil {
+		config.Expand = AllActions[S, A]
+	}
+	if config.Rollout == nil {
+		config.Rollout = Random[S, A]
+	}
+	if config.Backprop == nil {
+		config.Backprop = Negamax
+	}
+
+	t := &MCTS[S, A]{
+		root:   root[S, A](state.Clone().(S)),
+		config: config,
+	}
+	if config.Transpositions > 0 && config.Parallelism == TREE {
+		t.table = NewTable[*stats](config.Transpositions)
+	}
+	return t
+}
+
+// Root returns the root of the tree
+func (t *MCTS[S, A]) Root() *Node[S, A] {
+	return t.root
+}
+
+// Advance descends the tree along the played actions. When one of them was
+// never expanded, the search restarts from a new root holding state.
+func (t *MCTS[S, A]) Advance(state S, actions ...A) {
+	node := t.root
+	for _, action := range actions {
+		node = node.child(action)
+		if node == nil {
+			t.root = root[S, A](state.Clone().(S))
+			return
+		}
+	}
+
+	node.parent = nil
+	t.root = node
+}
+
+// Restrict limits the children of the root to actions, like the legal moves
+// given by a referee. Missing children are created and the others dropped,
+// so the search never returns an action outside of actions. It must not be
+// called while searching.
+func (t *MCTS[S, A]) Restrict(actions ...A) {
+	root := t.root
+	t.expand(root)
+
+	children := make([]*Node[S, A], 0, len(actions))
+	for _, action := range actions {
+		child := root.child(action)
+		if child == nil {
+			childState := root.state.Clone().(S)
+			childState.Exec(root.state.Player(), action)
+
+			child = NewNode(childState, root, action)
+			t.transpose(child)
+		}
+		children = append(children, child)
+	}
+
+	root.Lock()
+	root.children = children
+	root.proof = UNKNOWN
+	root.Unlock()
+
+	solve(root)
+}
+
+// Search runs the search from the root until ctx is done or the root is
+// solved, and returns the best action found. With TREE parallelism the
+// workers share the tree, every node is guarded by its own lock.
+func (t *MCTS[S, A]) Search(ctx context.Context) (A, bool) {
+	if t.config.Parallelism == ROOT {
+		return t.searchRoots(ctx)
+	}
+
+	root := t.root
+	t.searchTree(ctx, root, t.config.Workers, false)
+
+	_, visits := root.Stats()
+	t.log("MCTS: root visits", visits)
+
+	return best(root)
+}
+
+// Ponder grows the tree with workers goroutines until ctx is done, so the
+// statistics are there when the tree is advanced. The workers yield after
+// every simulation, a goroutine waiting for input gets the CPU at once. It
+// does nothing with ROOT parallelism, whose trees don't outlive a search.
+func (t *MCTS[S, A]) Ponder(ctx context.Context, workers int) {
+	if t.config.Parallelism == ROOT {
+		return
+	}
+	t.searchTree(ctx, t.root, workers, true)
+}
+
+// searchTree runs workers goroutines searching the shared tree from root,
+// yielding after every simulation if yield is set
+func (t *MCTS[S, A]) searchTree(ctx context.Context, root *Node[S, A], workers int, yield bool) {
+	var wg sync.WaitGroup
+
+	for i := 0; i < max(workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t.search(ctx, root, yield)
+		}()
+	}
+	wg.Wait()
+}
+
+// search runs simulations from root until ctx is done or root is solved.
+// Solved nodes are not simulated, their proof is backpropagated instead.
+func (t *MCTS[S, A]) search(ctx context.Context, root *Node[S, A], yield bool) {
+	for root.Proof() == UNKNOWN && !expired(ctx) {
+		node := t.treePolicy(root)
+
+		var played AMAF[A]
+		if t.config.RaveEquivalence > 0 {
+			played = AMAF[A]{}
+		}
+
+		var reward float64
+		if proof := node.Proof(); proof != UNKNOWN {
+			reward = proof.reward()
+		} else if r, ok := Playout(ctx, node.state, node.mover, t.config.Rollout, played); ok {
+			reward = r
+		} else {
+			t.revertVirtualLoss(node)
+			return
+		}
+		t.backpropagate(node, reward, played)
+
+		if yield {
+			runtime.Gosched()
+		}
+	}
+}
+
+// expired checks if ctx is done or past its deadline. Busy workers check the
+// deadline themselves, the timer closing ctx.Done can fire late when they
+// hold every CPU.
+func expired(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+
+	deadline, ok := ctx.Deadline()
+	return ok && !time.Now().Before(deadline)
+}
+
+// searchRoots gives every worker its own tree from a clone of the root
+// state, then merges the statistics of the root children by action and
+// returns the most visited one. The trees are dropped afterwards.
+func (t *MCTS[S, A]) searchRoots(ctx context.Context) (A, bool) {
+	roots := make([]*Node[S, A], max(t.config.Workers, 1))
+	var wg sync.WaitGroup
+
+	for i := range roots {
+		roots[i] = t.cloneRoot()
+
+		wg.Add(1)
+		go func(root *Node[S, A]) {
+			defer wg.Done()
+			t.search(ctx, root, false)
+		}(roots[i])
+	}
+	wg.Wait()
+
+	merged := root[S, A](t.root.state)
+	for _, r := range roots {
+		for _, child := range r.children {
+			m := merged.child(child.action)
+			if m == nil {
+				m = NewNode(child.state, merged, child.action)
+				merged.children = append(merged.children, m)
+				merged.expanded = true
+			}
+
+			m.stats.add(child.stats.wins, child.stats.visits)
+			if child.proof != UNKNOWN {
+				m.proof = child.proof
+			}
+		}
+		merged.stats.add(r.stats.wins, r.stats.visits)
+	}
+
+	t.log("MCTS: merged root visits", merged.stats.visits, "over", len(roots), "trees")
+
+	return best(merged)
+}
+
+// cloneRoot returns a new root holding a clone of the root state. The
+// children of an expanded root are copied without their statistics, so the
+// actions given to Restrict are the only ones searched.
+func (t *MCTS[S, A]) cloneRoot() *Node[S, A] {
+	clone := root[S, A](t.root.state.Clone().(S))
+	if !t.root.isExpanded() {
+		return clone
+	}
+
+	for _, child := range t.root.Children() {
+		clone.children = append(clone.children, NewNode(child.state.Clone().(S), clone, child.action))
+	}
+	clone.expanded = true
+	return clone
+}
+
+// log writes a line of statistics to the configured Log
+func (t *MCTS[S, A]) log(args ...any) {
+	if t.config.Log != nil {
+		fmt.Fprintln(t.config.Log, args...)
+	}
+}
+
+// treePolicy descends from node to a child not visited yet or solved,
+// expanding the nodes on its way, and adds a virtual loss to every node of
+// the path. Terminal nodes are proven on the way.
+func (t *MCTS[S, A]) treePolicy(node *Node[S, A]) *Node[S, A] {
+	loss := t.config.VirtualLoss
+	node.addVirtualLoss(loss)
+
+	for node.Proof() == UNKNOWN {
+		if node.state.IsEOG() {
+			node.prove(proofOf[A](node.state, node.mover))
+			break
+		}
+
+		t.expand(node)
+
+		child := t.config.Select(node)
+		if child == node {
+			return node
+		}
+
+		node = child
+		if !node.addVirtualLoss(loss) {
+			if node.state.IsEOG() {
+				node.prove(proofOf[A](node.state, node.mover))
+			}
+			return node
+		}
+	}
+	return node
+}
+
+// expand creates the children of node unless another worker already did
+func (t *MCTS[S, A]) expand(node *Node[S, A]) {
+	if node.isExpanded() {
+		return
+	}
+
+	node.Lock()
+	defer node.Unlock()
+
+	if node.expanded {
+		return
+	}
+
+	for _, action := range t.config.Expand(node.state) {
+		childState := node.state.Clone().(S)
+		childState.Exec(node.state.Player(), action)
+
+		child := NewNode(childState, node, action)
+		t.transpose(child)
+		node.children = append(node.children, child)
+	}
+	node.expanded = true
+}
+
+// transpose makes node share the statistics of the nodes already created for
+// its position, or registers its own for the next ones
+func (t *MCTS[S, A]) transpose(node *Node[S, A]) {
+	if t.table == nil {
+		return
+	}
+
+	hasher, ok := any(node.state).(Hasher)
+	if !ok {
+		return
+	}
+
+	hash := hasher.Hash()
+	if stats, ok := t.table.Get(hash); ok {
+		node.stats = stats
+		return
+	}
+	t.table.Put(hash, 0, node.stats)
+}
+
+// Playout simulates a game from state with policy and returns its result
+// from the perspective of mover. The moves are recorded in played unless it
+// is nil. It reports false when ctx is done before the game ends.
+func Playout[S State[A], A comparable](ctx context.Context, state S, mover Player, policy RolloutPolicy[S, A], played AMAF[A]) (float64, bool) {
+	stateClone := state.Clone().(S)
+	for !stateClone.IsEOG() {
+		select {
+		case <-ctx.Done():
+			return 0, false
+		default:
+			action := policy(stateClone, stateClone.Actions())
+			if played != nil {
+				played[action] = stateClone.Player()
+			}
+			stateClone.Exec(stateClone.Player(), action)
+		}
+	}
+	return float64(stateClone.Eval(mover)), true
+}
+
+// backpropagate updates every node from the leaf up to the root, replacing
+// the virtual loss of the path with the reward. Each node keeps its wins from
+// the perspective of the player who moved into it, the Backprop policy
+// converts the reward at every ply. When played is not nil the RAVE
+// statistics of the children along the path are updated too.
+func (t *MCTS[S, A]) backpropagate(node *Node[S, A], reward float64, played AMAF[A]) {
+	loss := t.config.VirtualLoss
+
+	for ; node != nil; node = node.parent {
+		node.stats.add(reward+float64(loss), 1-loss)
+
+		parentReward := t.config.Backprop(reward)
+
+		if played != nil {
+			updateRave(node, played, parentReward)
+			if node.parent != nil {
+				played[node.action] = node.mover
+			}
+		}
+
+		solve(node)
+		reward = parentReward
+	}
+}
+
+// revertVirtualLoss removes the virtual loss of a path whose simulation was
+// abandoned
+func (t *MCTS[S, A]) revertVirtualLoss(node *Node[S, A]) {
+	loss := t.config.VirtualLoss
+
+	for ; node != nil; node = node.parent {
+		node.stats.add(float64(loss), -loss)
+	}
+}
+
+// best returns the action of the child searched the most, the move to play
+// once the search is over. A proven win is always preferred and a proven
+// loss is only returned when every child is one.
+func best[S State[A], A comparable](node *Node[S, A]) (A, bool) {
+	var best *Node[S, A]
+	bestVisits := -1
+	for _, child := range node.Children() {
+		switch child.Proof() {
+		case WIN:
+			return child.action, true
+		case LOSS:
+			if best == nil {
+				best = child
+			}
+			continue
+		}
+
+		if _, visits := child.Stats(); visits > bestVisits {
+			best, bestVisits = child, visits
+		}
+	}
+
+	if best == nil {
+		var action A
+		return action, false
+	}
+	return best.action, true
+}
@@ -0,0 +1,157 @@
+package mcts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+var parallelisms = map[string]Parallelism{"tree": TREE, "root": ROOT}
+
+// configs returns the parallel configurations every search test runs with
+func configs() map[string]Config[*nim, int] {
+	configs := map[string]Config[*nim, int]{}
+	for kind, parallelism := range parallelisms {
+		for _, transpositions := range []int{0, 8} {
+			for _, rave := range []float64{0, 100} {
+				config := DefaultConfig[*nim, int]()
+				config.Workers = 4
+				config.Parallelism = parallelism
+				config.Transpositions = transpositions
+				config.RaveEquivalence = rave
+
+				name := fmt.Sprintf("%s/transpositions=%d/rave=%v", kind, transpositions, rave)
+				configs[name] = config
+			}
+		}
+	}
+	return configs
+}
+
+func search(tree *MCTS[*nim, int], d time.Duration) (int, bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	return tree.Search(ctx)
+}
+
+func TestSearchNim(t *testing.T) {
+	// the winning move leaves a multiple of 3
+	tests := []struct {
+		pile int
+		want int
+	}{
+		{1, 1},
+		{2, 2},
+		{4, 1},
+		{5, 2},
+		{10, 1},
+		{11, 2},
+	}
+
+	for name, config := range configs() {
+		for _, tt := range tests {
+			t.Run(fmt.Sprintf("%s/pile=%d", name, tt.pile), func(t *testing.T) {
+				tree := New(newNim(tt.pile), config)
+
+				if action, ok := search(tree, 200*time.Millisecond); !ok || action != tt.want {
+					t.Errorf("got move %d, want %d", action, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestSearchProvesRoot(t *testing.T) {
+	tests := []struct {
+		pile int
+		// proof of the root from the perspective of the player who moved
+		// into it
+		want Proof
+	}{
+		{3, WIN},
+		{4, LOSS},
+		{6, WIN},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("pile=%d", tt.pile), func(t *testing.T) {
+			config := DefaultConfig[*nim, int]()
+			config.Workers = 1
+			tree := New(newNim(tt.pile), config)
+
+			if _, ok := search(tree, time.Second); !ok {
+				t.Fatal("no move found")
+			}
+			if got := tree.Root().Proof(); got != tt.want {
+				t.Errorf("got proof %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestrict(t *testing.T) {
+	for name, config := range configs() {
+		t.Run(name, func(t *testing.T) {
+			// taking 1 wins, but only the losing move is allowed
+			tree := New(newNim(4), config)
+			tree.Restrict(2)
+
+			if action, ok := search(tree, 50*time.Millisecond); !ok || action != 2 {
+				t.Errorf("got move %d, want the only allowed move 2", action)
+			}
+			if n := len(tree.Root().Children()); n != 1 {
+				t.Errorf("got %d root children, want 1", n)
+			}
+		})
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	config := DefaultConfig[*nim, int]()
+	config.Workers = 1
+
+	state := newNim(20)
+	tree := New(state, config)
+	search(tree, 50*time.Millisecond)
+
+	// the statistics below the played moves are kept
+	state.Exec(state.Player(), 1)
+	state.Exec(state.Player(), 2)
+	tree.Advance(state, 1, 2)
+
+	if _, visits := tree.Root().Stats(); visits == 0 {
+		t.Error("advanced root lost its visits")
+	}
+	if tree.Root().State().pile != 17 {
+		t.Errorf("advanced root has pile %d, want 17", tree.Root().State().pile)
+	}
+
+	// an action never expanded restarts from state
+	other := newNim(9)
+	tree.Advance(other, 3)
+
+	if _, visits := tree.Root().Stats(); visits != 0 {
+		t.Errorf("new root has %d visits, want 0", visits)
+	}
+	if tree.Root().State().pile != 9 {
+		t.Errorf("new root has pile %d, want 9", tree.Root().State().pile)
+	}
+}
+
+func TestLog(t *testing.T) {
+	for kind, parallelism := range parallelisms {
+		var log bytes.Buffer
+
+		config := DefaultConfig[*nim, int]()
+		config.Parallelism = parallelism
+		config.Log = &log
+		search(New(newNim(7), config), 10*time.Millisecond)
+
+		if !strings.HasPrefix(log.String(), "MCTS: ") {
+			t.Errorf("%s: got log %q, want the search statistics", kind, log.String())
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package mcts
+
+import "sync"
+
+// stats are the wins and visits of a node, guarded by their own lock
+type stats struct {
+	wins   float64
+	visits int
+
+	sync.Mutex
+}
+
+type Node[S State[A], A comparable] struct {
+	state    S
+	parent   *Node[S, A]
+	children []*Node[S, A]
+	action   A
+
+	// stats are shared by the nodes of the same position when transpositions
+	// are enabled
+	stats *stats
+
+	// mover is the player who played action, the statistics of the node are
+	// from its perspective
+	mover Player
+
+	// expanded is set once the children are created, they are never
+	// changed afterwards so they can be read without locking
+	expanded bool
+
+	// proof is set once the node is solved
+	proof Proof
+
+	// all-moves-as-first statistics of action, kept when RAVE is enabled
+	raveWins   float64
+	raveVisits int
+
+	sync.RWMutex
+}
+
+func NewNode[S State[A], A comparable](state S, parent *Node[S, A], action A) *Node[S, A] {
+	node := &Node[S, A]{
+		state:    state,
+		parent:   parent,
+		action:   action,
+		children: make([]*Node[S, A], 0),
+		stats:    &stats{},
+	}
+
+	if parent != nil {
+		node.mover = parent.state.Player()
+	}
+	return node
+}
+
+func root[S State[A], A comparable](state S) *Node[S, A] {
+	var action A
+	return NewNode[S, A](state, nil, action)
+}
+
+// State returns the state of the node, it must not be modified
+func (n *Node[S, A]) State() S {
+	return n.state
+}
+
+// Action returns the action leading to the node
+func (n *Node[S, A]) Action() A {
+	return n.action
+}
+
+// Children returns the children of the node, nil until it is expanded
+func (n *Node[S, A]) Children() []*Node[S, A] {
+	if !n.isExpanded() {
+		return nil
+	}
+	return n.children
+}
+
+func (n *Node[S, A]) isExpanded() bool {
+	n.RLock()
+	defer n.RUnlock()
+
+	return n.expanded
+}
+
+// Stats returns the wins and visits of the node
+func (n *Node[S, A]) Stats() (float64, int) {
+	n.stats.Lock()
+	defer n.stats.Unlock()
+
+	return n.stats.wins, n.stats.visits
+}
+
+// addVirtualLoss counts loss pending visits lost by the player who moved into
+// the node, steering the other workers to different branches until the
+// simulation is backpropagated. It reports if the node was visited before.
+func (n *Node[S, A]) addVirtualLoss(loss int) bool {
+	return n.stats.add(-float64(loss), loss) > 0
+}
+
+// add adds wins and visits and returns the visits before
+func (s *stats) add(wins float64, visits int) int {
+	s.Lock()
+	defer s.Unlock()
+
+	before := s.visits
+	s.visits += visits
+	s.wins += wins
+	return before
+}
+
+// child returns the child reached by action, nil if it was not expanded
+func (n *Node[S, A]) child(action A) *Node[S, A] {
+	for _, child := range n.Children() {
+		if child.action == action {
+			return child
+		}
+	}
+	return nil
+}
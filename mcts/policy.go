@@ -0,0 +1,86 @@
+package mcts
+
+import (
+	"math"
+	"math/rand"
+)
+
+// SelectPolicy picks the child of an expanded node to descend to. It
+// returns node itself when no child may be selected.
+type SelectPolicy[S State[A], A comparable] func(node *Node[S, A]) *Node[S, A]
+
+// ExpandPolicy returns the actions a node is expanded with, in the order
+// their children are tried
+type ExpandPolicy[S State[A], A comparable] func(state S) []A
+
+// RolloutPolicy picks the move played by the simulation among actions
+type RolloutPolicy[S State[A], A comparable] func(state S, actions []A) A
+
+// BackpropPolicy converts the reward of a node to the reward of its parent
+type BackpropPolicy func(reward float64) float64
+
+// UCT selects the child with the highest upper confidence bound, blended
+// with its RAVE value when equivalence is positive. Unvisited children are
+// tried first, a child proven a win is selected at once and a child proven a
+// loss never.
+func UCT[S State[A], A comparable](c, equivalence float64) SelectPolicy[S, A] {
+	return func(node *Node[S, A]) *Node[S, A] {
+		max := math.Inf(-1)
+		var nodes []*Node[S, A]
+
+		_, parentVisits := node.Stats()
+		for _, child := range node.Children() {
+			switch child.Proof() {
+			case WIN:
+				return child
+			case LOSS:
+				continue
+			}
+
+			wins, visits := child.Stats()
+			if visits == 0 {
+				return child
+			}
+
+			value := wins / float64(visits)
+			if equivalence > 0 {
+				value = RaveValue(child, value, visits, equivalence)
+			}
+
+			uctValue := value + c*math.Sqrt(math.Log(float64(parentVisits))/float64(visits))
+
+			if uctValue < max {
+				continue
+			}
+
+			if uctValue == max {
+				nodes = append(nodes, child)
+				continue
+			}
+
+			max = uctValue
+			nodes = []*Node[S, A]{child}
+		}
+
+		if len(nodes) <= 0 {
+			return node
+		}
+
+		return nodes[rand.Intn(len(nodes))]
+	}
+}
+
+// AllActions expands a node with every legal action of its state
+func AllActions[S State[A], A comparable](state S) []A {
+	return state.Actions()
+}
+
+// Random plays uniformly at random
+func Random[S State[A], A comparable](state S, actions []A) A {
+	return actions[rand.Intn(len(actions))]
+}
+
+// Negamax flips the reward at every ply, for two-player zero-sum games
+func Negamax(reward float64) float64 {
+	return -reward
+}
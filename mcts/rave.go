@@ -0,0 +1,46 @@
+package mcts
+
+import "math"
+
+// AMAF records which player played every action of a simulation, both in
+// the tree and in the rollout. It suits games where an action is played at
+// most once, like the cells of a tic-tac-toe board.
+type AMAF[A comparable] map[A]Player
+
+// RaveStats returns the all-moves-as-first wins and visits of the node
+func (n *Node[S, A]) RaveStats() (float64, int) {
+	n.RLock()
+	defer n.RUnlock()
+
+	return n.raveWins, n.raveVisits
+}
+
+// updateRave credits the children of node whose action was played later in
+// the simulation by the player to move at node, as if it had been played
+// first. reward is from the perspective of the children.
+func updateRave[S State[A], A comparable](node *Node[S, A], played AMAF[A], reward float64) {
+	mover := node.state.Player()
+	for _, child := range node.Children() {
+		if p, ok := played[child.action]; !ok || p != mover {
+			continue
+		}
+
+		child.Lock()
+		child.raveVisits++
+		child.raveWins += reward
+		child.Unlock()
+	}
+}
+
+// RaveValue blends value, the mean reward of child over visits, with its
+// RAVE value. The weight of RAVE fades as the child gets visited, reaching
+// a half at equivalence visits.
+func RaveValue[S State[A], A comparable](child *Node[S, A], value float64, visits int, equivalence float64) float64 {
+	raveWins, raveVisits := child.RaveStats()
+	if raveVisits == 0 {
+		return value
+	}
+
+	beta := math.Sqrt(equivalence / (3*float64(visits) + equivalence))
+	return (1-beta)*value + beta*raveWins/float64(raveVisits)
+}
@@ -0,0 +1,85 @@
+package mcts
+
+// Proof is the game-theoretic value of a node once it is solved, from the
+// perspective of the player who moved into it
+type Proof int8
+
+const (
+	UNKNOWN Proof = iota
+	WIN
+	LOSS
+	DRAW
+)
+
+// reward returns the result backpropagated for a solved node
+func (p Proof) reward() float64 {
+	switch p {
+	case WIN:
+		return 1.0
+	case LOSS:
+		return -1.0
+	}
+	return 0
+}
+
+// proofOf returns the proof of a terminal state for mover
+func proofOf[A comparable](state State[A], mover Player) Proof {
+	switch eval := state.Eval(mover); {
+	case eval > 0:
+		return WIN
+	case eval < 0:
+		return LOSS
+	}
+	return DRAW
+}
+
+// Proof returns the proof of the node, UNKNOWN until it is solved
+func (n *Node[S, A]) Proof() Proof {
+	n.RLock()
+	defer n.RUnlock()
+
+	return n.proof
+}
+
+func (n *Node[S, A]) prove(p Proof) {
+	n.Lock()
+	defer n.Unlock()
+
+	if n.proof == UNKNOWN {
+		n.proof = p
+	}
+}
+
+// solve proves node from its children: the player to move wins with any
+// child proven a win for them, so node is a loss for the player who moved
+// into it. It is a win only when every child is proven a loss, and a draw
+// when every child is proven and the best of them is a draw.
+func solve[S State[A], A comparable](node *Node[S, A]) {
+	if node.Proof() != UNKNOWN || !node.isExpanded() || len(node.children) == 0 {
+		return
+	}
+
+	draw, unknown := false, false
+	for _, child := range node.children {
+		switch child.Proof() {
+		case WIN:
+			node.prove(LOSS)
+			return
+		case DRAW:
+			draw = true
+		case UNKNOWN:
+			unknown = true
+		}
+	}
+
+	// a win may follow unproven children, only then is the node undecided
+	if unknown {
+		return
+	}
+
+	if draw {
+		node.prove(DRAW)
+		return
+	}
+	node.prove(WIN)
+}
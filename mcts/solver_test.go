@@ -0,0 +1,98 @@
+package mcts
+
+import "testing"
+
+// nim is a subtraction game: players take 1 or 2 stones from a pile and the
+// one taking the last stone wins. The player to move loses when the pile is
+// a multiple of 3.
+type nim struct {
+	pile   int
+	player Player
+	last   Player
+}
+
+func newNim(pile int) *nim {
+	return &nim{pile: pile, player: 1}
+}
+
+func (n *nim) Clone() State[int] {
+	clone := *n
+	return &clone
+}
+
+func (n *nim) Actions() []int {
+	var actions []int
+	for take := 1; take <= min(2, n.pile); take++ {
+		actions = append(actions, take)
+	}
+	return actions
+}
+
+func (n *nim) IsEOG() bool    { return n.pile == 0 }
+func (n *nim) Player() Player { return n.player }
+
+// Hash identifies the position, the same pile is reached by different
+// move orders
+func (n *nim) Hash() uint64 { return uint64(n.pile)<<2 | uint64(n.player) }
+
+func (n *nim) Exec(p Player, take int) {
+	n.pile -= take
+	n.last = p
+	n.player = 3 - p
+}
+
+func (n *nim) Eval(p Player) Result {
+	switch {
+	case n.pile > 0:
+		return 0
+	case n.last == p:
+		return 1
+	}
+	return -1
+}
+
+// expanded returns a node whose children carry the given proofs
+func expanded(proofs ...Proof) *Node[*nim, int] {
+	node := root[*nim, int](newNim(5))
+	for i, proof := range proofs {
+		child := NewNode(newNim(4), node, i)
+		child.proof = proof
+		node.children = append(node.children, child)
+	}
+	node.expanded = true
+	return node
+}
+
+func TestSolve(t *testing.T) {
+	tests := []struct {
+		name   string
+		proofs []Proof
+		want   Proof
+	}{
+		{"win after unknown", []Proof{UNKNOWN, LOSS, WIN}, LOSS},
+		{"win first", []Proof{WIN, UNKNOWN}, LOSS},
+		{"all losses", []Proof{LOSS, LOSS, LOSS}, WIN},
+		{"draws and losses", []Proof{LOSS, DRAW, LOSS}, DRAW},
+		{"unknown without win", []Proof{LOSS, UNKNOWN, DRAW}, UNKNOWN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := expanded(tt.proofs...)
+			solve(node)
+
+			if got := node.Proof(); got != tt.want {
+				t.Errorf("got proof %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSolveUnexpanded(t *testing.T) {
+	node := root[*nim, int](newNim(5))
+	solve(node)
+
+	if got := node.Proof(); got != UNKNOWN {
+		t.Errorf("got proof %v, want %v", got, UNKNOWN)
+	}
+}
@@ -0,0 +1,61 @@
+package mcts
+
+import "sync"
+
+// Hasher is a State with a hash identifying its position, so move orders
+// reaching the same position can share their results
+type Hasher interface {
+	Hash() uint64
+}
+
+type slot[V any] struct {
+	key      uint64
+	priority int
+	value    V
+	used     bool
+}
+
+// Table is a fixed-size transposition table indexed by the low bits of the
+// hash. A slot holding another position is only replaced by an entry of at
+// least the same priority, like a deeper search. It is safe for concurrent
+// use.
+type Table[V any] struct {
+	slots []slot[V]
+	mask  uint64
+
+	sync.Mutex
+}
+
+// NewTable returns a table of 2^bits slots
+func NewTable[V any](bits int) *Table[V] {
+	return &Table[V]{
+		slots: make([]slot[V], 1<<bits),
+		mask:  1<<bits - 1,
+	}
+}
+
+// Get returns the value stored for key
+func (t *Table[V]) Get(key uint64) (V, bool) {
+	t.Lock()
+	defer t.Unlock()
+
+	s := &t.slots[key&t.mask]
+	if !s.used || s.key != key {
+		var value V
+		return value, false
+	}
+	return s.value, true
+}
+
+// Put stores value for key unless its slot holds another position of a
+// higher priority
+func (t *Table[V]) Put(key uint64, priority int, value V) {
+	t.Lock()
+	defer t.Unlock()
+
+	s := &t.slots[key&t.mask]
+	if s.used && s.key != key && s.priority > priority {
+		return
+	}
+	*s = slot[V]{key: key, priority: priority, value: value, used: true}
+}
@@ -0,0 +1,35 @@
+package mcts
+
+import "testing"
+
+func TestTable(t *testing.T) {
+	table := NewTable[string](2)
+
+	if _, ok := table.Get(1); ok {
+		t.Fatal("empty table found a value")
+	}
+
+	table.Put(1, 3, "deep")
+	if value, ok := table.Get(1); !ok || value != "deep" {
+		t.Fatalf("got %q, %v, want the stored value", value, ok)
+	}
+
+	// 5 shares the slot of 1: a lower priority doesn't replace it, the same
+	// key always does
+	table.Put(5, 2, "shallow")
+	if _, ok := table.Get(5); ok {
+		t.Error("lower priority replaced another position")
+	}
+	table.Put(1, 0, "updated")
+	if value, _ := table.Get(1); value != "updated" {
+		t.Errorf("got %q, want the updated value", value)
+	}
+
+	table.Put(5, 4, "deeper")
+	if value, ok := table.Get(5); !ok || value != "deeper" {
+		t.Errorf("got %q, %v, want the higher priority value", value, ok)
+	}
+	if _, ok := table.Get(1); ok {
+		t.Error("replaced position still found")
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/mendel/codingames/mcts"
+)
+
+// Counter is a State that can count its empty cells left to play
+type Counter interface {
+	Empties() int
+}
+
+type bound int8
+
+const (
+	EXACT bound = iota
+	LOWER
+	UPPER
+)
+
+type entry struct {
+	depth int
+	score float64
+	bound bound
+	best  Action
+
+	// cut is set when the depth limit was reached below the position
+	cut bool
+}
+
+// heuristic scales the evaluation of a position cut by the depth limit, so
+// it never reaches the value of a proven win or loss
+const heuristic = 0.5
+
+// Solver runs an iterative-deepening negamax with alpha-beta pruning over a
+// State, ordering the moves by their static evaluation and the best move
+// stored in its transposition table. The table keeps the deepest searches
+// when positions collide.
+type Solver struct {
+	table *mcts.Table[entry]
+
+	// cut is set when a search reaches the depth limit on a position that
+	// is not over, its result is then not proven
+	cut   bool
+	nodes int
+}
+
+// NewSolver returns a solver with a transposition table of 2^bits entries
+func NewSolver(bits int) *Solver {
+	return &Solver{table: mcts.NewTable[entry](bits)}
+}
+
+// Solve searches state until ctx is done or its result is proven. It returns
+// the best move of the deepest complete iteration and its proof from the
+// perspective of the player to move, UNKNOWN when it is not proven.
+func (s *Solver) Solve(ctx context.Context, state State) (Action, mcts.Proof) {
+	var best Action
+	proof := mcts.UNKNOWN
+
+	for depth := 1; ; depth++ {
+		s.cut = false
+		s.nodes = 0
+
+		score, action, ok := s.root(ctx, state, depth)
+		if !ok {
+			break
+		}
+		best = action
+
+		switch {
+		case score >= 1:
+			proof = mcts.WIN
+		case score <= -1:
+			proof = mcts.LOSS
+		case !s.cut:
+			proof = mcts.DRAW
+		}
+
+		fmt.Fprintf(os.Stderr, "SOLVER: depth %d, nodes %d, score %.2f\n", depth, s.nodes, score)
+
+		if proof != mcts.UNKNOWN {
+			break
+		}
+	}
+
+	return best, proof
+}
+
+func (s *Solver) root(ctx context.Context, state State, depth int) (float64, Action, bool) {
+	alpha, beta := -2.0, 2.0
+	var best Action
+
+	for _, action := range s.order(state, nil) {
+		child := state.Clone()
+		child.Exec(state.Player(), action)
+
+		score, ok := s.negamax(ctx, child, depth-1, -beta, -alpha)
+		if !ok {
+			return 0, nil, false
+		}
+		score = -score
+
+		if best == nil || score > alpha {
+			alpha, best = score, action
+		}
+	}
+
+	return alpha, best, true
+}
+
+// negamax returns the score of state from the perspective of the player to
+// move, or false when ctx is done
+func (s *Solver) negamax(ctx context.Context, state State, depth int, alpha, beta float64) (float64, bool) {
+	select {
+	case <-ctx.Done():
+		return 0, false
+	default:
+	}
+	s.nodes++
+
+	player := state.Player()
+	if state.IsEOG() {
+		return float64(state.Eval(player)), true
+	}
+	if depth <= 0 {
+		s.cut = true
+		return heuristic * float64(state.Eval(player)), true
+	}
+
+	hasher, hashed := state.(mcts.Hasher)
+
+	var hint Action
+	if hashed {
+		if e, ok := s.table.Get(hasher.Hash()); ok {
+			hint = e.best
+			if e.depth >= depth && (e.bound == EXACT ||
+				e.bound == LOWER && e.score >= beta ||
+				e.bound == UPPER && e.score <= alpha) {
+				s.cut = s.cut || e.cut
+				return e.score, true
+			}
+		}
+	}
+
+	// track the cuts below this position apart to store them
+	parentCut := s.cut
+	s.cut = false
+
+	origAlpha := alpha
+	best, bestScore := Action(nil), -2.0
+	for _, action := range s.order(state, hint) {
+		child := state.Clone()
+		child.Exec(player, action)
+
+		score, ok := s.negamax(ctx, child, depth-1, -beta, -alpha)
+		if !ok {
+			return 0, false
+		}
+		score = -score
+
+		if score > bestScore {
+			best, bestScore = action, score
+		}
+		alpha = max(alpha, score)
+		if alpha >= beta {
+			break
+		}
+	}
+
+	cut := s.cut
+	s.cut = parentCut || cut
+
+	if hashed {
+		e := entry{depth: depth, score: bestScore, bound: EXACT, best: best, cut: cut}
+		switch {
+		case bestScore <= origAlpha:
+			e.bound = UPPER
+		case bestScore >= beta:
+			e.bound = LOWER
+		}
+		s.table.Put(hasher.Hash(), depth, e)
+	}
+
+	return bestScore, true
+}
+
+// order returns the actions of state, hint first and the others by the
+// static evaluation of the position they lead to
+func (s *Solver) order(state State, hint Action) []Action {
+	actions := state.Actions()
+	player := state.Player()
+
+	scores := make(map[Action]Result, len(actions))
+	for _, action := range actions {
+		child := state.Clone()
+		child.Exec(player, action)
+		scores[action] = child.Eval(player)
+	}
+
+	sort.SliceStable(actions, func(i, j int) bool {
+		if actions[i] == hint {
+			return true
+		}
+		if actions[j] == hint {
+			return false
+		}
+		return scores[actions[i]] > scores[actions[j]]
+	})
+
+	return actions
+}
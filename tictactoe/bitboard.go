@@ -0,0 +1,211 @@
+package main
+
+import "math/bits"
+
+// FULL is the mask of a sub-board or macro board with all 9 cells set
+const FULL uint16 = 1<<9 - 1
+
+// winLines holds the masks of the rows, columns and diagonals of a 3×3 board
+var winLines = [8]uint16{
+	0b000_000_111, 0b000_111_000, 0b111_000_000,
+	0b001_001_001, 0b010_010_010, 0b100_100_100,
+	0b100_010_001, 0b001_010_100,
+}
+
+// wins tells for every 9-bit mask if it contains a complete line
+var wins = func() (wins [1 << 9]bool) {
+	for mask := range wins {
+		for _, line := range winLines {
+			if uint16(mask)&line == line {
+				wins[mask] = true
+				break
+			}
+		}
+	}
+	return wins
+}()
+
+// BitBoard is a compact Ultimate Tic-Tac-Toe state. Every sub-board keeps a
+// 9-bit mask of the cells of each player, and the macro board keeps the
+// masks of the sub-boards won by each player or drawn.
+type BitBoard struct {
+	cells  [2][9]uint16
+	macro  [2]uint16
+	drawn  uint16
+	player Player
+
+	// target is the sub-board the next move must be played in, -1 when the
+	// choice is free
+	target int8
+
+	// hash is the Zobrist hash of the position, keyed like Game's
+	hash uint64
+}
+
+func NewBitBoard() *BitBoard {
+	return &BitBoard{
+		player: PLAYER,
+		target: -1,
+		hash:   zobrist(zobristPlayer, int(PLAYER)) ^ zobrist(zobristTarget, 0),
+	}
+}
+
+// idx maps a player to its masks
+func idx(p Player) int {
+	return int(p) - 1
+}
+
+func (b *BitBoard) closed() uint16 {
+	return b.macro[0] | b.macro[1] | b.drawn
+}
+
+// Clone creates a copy of the game state
+func (b *BitBoard) Clone() State {
+	clone := *b
+	return &clone
+}
+
+// Actions returns a list of possible moves from the current state
+func (b *BitBoard) Actions() []Action {
+	if b.IsEOG() {
+		return nil
+	}
+
+	actions := make([]Action, 0, 9)
+	for sub := 0; sub < 9; sub++ {
+		if b.closed()&(1<<sub) != 0 || (b.target >= 0 && int(b.target) != sub) {
+			continue
+		}
+
+		free := FULL &^ (b.cells[0][sub] | b.cells[1][sub])
+		for ; free != 0; free &= free - 1 {
+			actions = append(actions, toMove(sub, bits.TrailingZeros16(free)))
+		}
+	}
+
+	return actions
+}
+
+// Exec applies a move to the game state
+func (b *BitBoard) Exec(p Player, action Action) {
+	if action == nil {
+		return
+	}
+
+	move := action.(Move)
+	sub, cell := fromMove(move)
+	mine := &b.cells[idx(p)][sub]
+	*mine |= 1 << cell
+
+	if wins[*mine] {
+		b.macro[idx(p)] |= 1 << sub
+	} else if b.cells[0][sub]|b.cells[1][sub] == FULL {
+		b.drawn |= 1 << sub
+	}
+
+	target := b.target
+	b.target = int8(cell)
+	if b.closed()&(1<<cell) != 0 {
+		b.target = -1
+	}
+
+	b.hash ^= zobrist(int(p), move.Row*SIZE*SIZE+move.Col)
+	b.hash ^= zobrist(zobristTarget, int(target)+1) ^ zobrist(zobristTarget, int(b.target)+1)
+	b.hash ^= zobrist(zobristPlayer, int(b.player)) ^ zobrist(zobristPlayer, int(3-p))
+	b.player = 3 - p
+}
+
+// IsEOG checks if the game is over
+func (b *BitBoard) IsEOG() bool {
+	return b.Winner() != OPEN
+}
+
+// Winner returns the owner of the macro board, following the same rules as
+// Game.Winner
+func (b *BitBoard) Winner() Owner {
+	for _, p := range []Player{PLAYER, OPPONENT} {
+		if wins[b.macro[idx(p)]] {
+			return Owner(p)
+		}
+	}
+
+	if b.closed() != FULL {
+		return OPEN
+	}
+
+	player := bits.OnesCount16(b.macro[idx(PLAYER)])
+	opponent := bits.OnesCount16(b.macro[idx(OPPONENT)])
+	switch {
+	case player > opponent:
+		return Owner(PLAYER)
+	case player < opponent:
+		return Owner(OPPONENT)
+	}
+	return DRAWN
+}
+
+// Eval evaluates the game state and returns the result from the perspective
+// of the given player, weighting the sub-boards like Board.Eval does
+func (b *BitBoard) Eval(p Player) Result {
+	switch b.Winner() {
+	case Owner(p):
+		return 1.0
+	case Owner(3 - p):
+		return -1.0
+	case DRAWN:
+		return 0
+	}
+
+	var eval Result
+	for sub := 0; sub < 9; sub++ {
+		switch {
+		case b.macro[idx(p)]&(1<<sub) != 0:
+			eval += 1.0
+		case b.macro[idx(3-p)]&(1<<sub) != 0:
+			eval -= 1.0
+		default:
+			var score float64
+			for _, line := range winLines {
+				mine := bits.OnesCount16(b.cells[idx(p)][sub] & line)
+				theirs := bits.OnesCount16(b.cells[idx(3-p)][sub] & line)
+				score += lineValue(mine, theirs, SIZE)
+			}
+			eval += Result(score / float64(len(winLines)))
+		}
+	}
+	return eval / 9
+}
+
+// Empties returns the number of empty cells in the sub-boards still open
+func (b *BitBoard) Empties() int {
+	empties := 0
+	for sub := 0; sub < 9; sub++ {
+		if b.closed()&(1<<sub) == 0 {
+			empties += 9 - bits.OnesCount16(b.cells[0][sub]|b.cells[1][sub])
+		}
+	}
+	return empties
+}
+
+// Hash returns the Zobrist hash of the position
+func (b *BitBoard) Hash() uint64 {
+	return b.hash
+}
+
+// Player returns the current player
+func (b *BitBoard) Player() Player {
+	return b.player
+}
+
+// toMove converts a sub-board and a cell to global coordinates
+func toMove(sub, cell int) Move {
+	return Move{
+		Row: sub/SIZE*SIZE + cell/SIZE,
+		Col: sub%SIZE*SIZE + cell%SIZE,
+	}
+}
+
+// fromMove converts global coordinates to a sub-board and a cell
+func fromMove(m Move) (sub, cell int) {
+	return m.Row/SIZE*SIZE + m.Col/SIZE, m.Row%SIZE*SIZE + m.Col%SIZE
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/mendel/codingames/mcts"
+)
+
+// TestBitBoardMatchesGame plays random games on both representations and
+// compares them after every move
+func TestBitBoardMatchesGame(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 200; i++ {
+		game, bitboard := states["game"](), states["bitboard"]()
+
+		for ply := 0; ; ply++ {
+			gameActions, bitboardActions := game.Actions(), bitboard.Actions()
+			if !sameMoves(gameActions, bitboardActions) {
+				t.Fatalf("game %d, ply %d: actions %v, bitboard %v", i, ply, gameActions, bitboardActions)
+			}
+
+			if g, b := game.(*Game).Winner(), bitboard.(*BitBoard).Winner(); g != b {
+				t.Fatalf("game %d, ply %d: winner %v, bitboard %v", i, ply, g, b)
+			}
+			if g, b := game.(mcts.Hasher).Hash(), bitboard.(mcts.Hasher).Hash(); g != b {
+				t.Fatalf("game %d, ply %d: hash %x, bitboard %x", i, ply, g, b)
+			}
+			if g, b := game.(Counter).Empties(), bitboard.(Counter).Empties(); g != b {
+				t.Fatalf("game %d, ply %d: empties %d, bitboard %d", i, ply, g, b)
+			}
+			for _, p := range []Player{PLAYER, OPPONENT} {
+				if g, b := game.Eval(p), bitboard.Eval(p); math.Abs(float64(g-b)) > 1e-9 {
+					t.Fatalf("game %d, ply %d: eval for %d %v, bitboard %v", i, ply, p, g, b)
+				}
+			}
+			if g, b := game.IsEOG(), bitboard.IsEOG(); g != b {
+				t.Fatalf("game %d, ply %d: end of game %v, bitboard %v", i, ply, g, b)
+			}
+
+			if game.IsEOG() {
+				break
+			}
+
+			action := gameActions[rng.Intn(len(gameActions))]
+			game.Exec(game.Player(), action)
+			bitboard.Exec(bitboard.Player(), action)
+		}
+	}
+}
+
+func benchmarkRollout(b *testing.B, name string) {
+	state := states[name]()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mcts.Playout(context.Background(), state, state.Player(), randomRollout, nil)
+	}
+}
+
+func BenchmarkRolloutGame(b *testing.B)     { benchmarkRollout(b, "game") }
+func BenchmarkRolloutBitBoard(b *testing.B) { benchmarkRollout(b, "bitboard") }
@@ -1,5 +1,7 @@
 package main
 
+// SIZE is the size of the boards of Ultimate Tic-Tac-Toe, and the number of
+// cells in a row winning them
 const SIZE = 3
 
 type Move struct {
@@ -7,13 +9,25 @@ type Move struct {
 	Col int
 }
 
+// Board is a size×size grid won by k cells in a row of the same player,
+// 3×3 with k = 3 for tic-tac-toe, 15×15 with k = 5 for Gomoku
 type Board struct {
 	board  [][]int
 	player Player
 	size   int
+	k      int
+	hash   uint64
+
+	// winner is the first player with k cells in a row, EMPTY while there is
+	// none, and filled the number of cells played
+	winner Player
+	filled int
 }
 
-func NewBoard(size int) *Board {
+// directions are the steps along rows, columns and both diagonals
+var directions = [4][2]int{{0, 1}, {1, 0}, {1, 1}, {1, -1}}
+
+func NewBoard(size, k int) *Board {
 	board := make([][]int, size)
 	for i := range board {
 		board[i] = make([]int, size)
@@ -26,6 +40,8 @@ func NewBoard(size int) *Board {
 		board:  board,
 		player: PLAYER,
 		size:   size,
+		k:      k,
+		hash:   zobrist(zobristPlayer, int(PLAYER)),
 	}
 }
 
@@ -40,6 +56,10 @@ func (b *Board) Clone() State {
 		board:  newBoard,
 		player: b.player,
 		size:   b.size,
+		k:      b.k,
+		hash:   b.hash,
+		winner: b.winner,
+		filled: b.filled,
 	}
 }
 
@@ -66,34 +86,100 @@ func (b *Board) Exec(p Player, action Action) {
 	}
 
 	move := action.(Move)
-	b.board[move.Row%b.size][move.Col%b.size] = int(b.player)
-	b.player = 3 - b.player
+	row, col := move.Row%b.size, move.Col%b.size
+	if b.board[row][col] != int(EMPTY) {
+		return
+	}
+	b.board[row][col] = int(p)
+	b.hash ^= zobrist(int(p), row*b.size+col)
+
+	b.filled++
+	if b.winner == EMPTY && b.inRow(row, col, int(p)) {
+		b.winner = p
+	}
+
+	b.hash ^= zobrist(zobristPlayer, int(b.player)) ^ zobrist(zobristPlayer, int(3-p))
+	b.player = 3 - p
 }
 
 // IsEOG checks if the game is over
 func (b *Board) IsEOG() bool {
-	return b.checkWin(int(PLAYER)) || b.checkWin(int(OPPONENT)) || b.isBoardFull()
+	return b.winner != EMPTY || b.isBoardFull()
 }
 
 // Eval evaluates the game state and returns the result from the perspective of the given player
 func (b *Board) Eval(player Player) Result {
-	// Example: Add weights to different winning scenarios or positions
 	if b.checkWin(int(player)) {
 		return 1.0
 	}
 	if b.checkWin(3 - int(player)) {
 		return -1.0
 	}
-	// Evaluate based on control of center, corners, etc.
-	controlScore := 0.0
-	if b.board[1][1] == int(player) {
-		controlScore += 0.5 // Center control
+
+	// Every line of k cells still open to a single player is worth the
+	// square of its share of that player's cells, averaged over the lines
+	var score float64
+	lines := 0
+	for row := 0; row < b.size; row++ {
+		for col := 0; col < b.size; col++ {
+			for _, d := range directions {
+				endRow, endCol := row+(b.k-1)*d[0], col+(b.k-1)*d[1]
+				if endRow >= b.size || endCol < 0 || endCol >= b.size {
+					continue
+				}
+
+				mine, theirs := 0, 0
+				for i := 0; i < b.k; i++ {
+					switch b.board[row+i*d[0]][col+i*d[1]] {
+					case int(player):
+						mine++
+					case 3 - int(player):
+						theirs++
+					}
+				}
+				score += lineValue(mine, theirs, b.k)
+				lines++
+			}
+		}
 	}
-	if b.board[0][0] == int(player) || b.board[0][2] == int(player) ||
-		b.board[2][0] == int(player) || b.board[2][2] == int(player) {
-		controlScore += 0.25 // Corner control
+
+	if lines == 0 {
+		return 0
 	}
-	return Result(controlScore)
+	return Result(score / float64(lines))
+}
+
+// lineValue rates a line of k cells holding mine and theirs cells of each
+// player, a line holding both players' cells can't be won anymore
+func lineValue(mine, theirs, k int) float64 {
+	switch {
+	case theirs == 0:
+		share := float64(mine) / float64(k)
+		return share * share
+	case mine == 0:
+		share := float64(theirs) / float64(k)
+		return -share * share
+	}
+	return 0
+}
+
+// Owner returns who won the board, DRAWN when it is full without a winner
+// and OPEN otherwise
+func (b *Board) Owner() Owner {
+	switch {
+	case b.checkWin(int(PLAYER)):
+		return Owner(PLAYER)
+	case b.checkWin(int(OPPONENT)):
+		return Owner(OPPONENT)
+	case b.isBoardFull():
+		return DRAWN
+	}
+	return OPEN
+}
+
+// Hash returns the Zobrist hash of the cells and the player to move
+func (b *Board) Hash() uint64 {
+	return b.hash
 }
 
 // Player returns the current player
@@ -104,62 +190,31 @@ func (b *Board) Player() Player {
 // Helper methods
 
 func (b *Board) checkWin(player int) bool {
-	// Check rows
-	for row := 0; row < b.size; row++ {
-		win := true
-		for col := 0; col < b.size; col++ {
-			if b.board[row][col] != player {
-				win = false
-				break
-			}
-		}
-		if win {
-			return true
-		}
-	}
-	// Check columns
-	for col := 0; col < b.size; col++ {
-		win := true
-		for row := 0; row < b.size; row++ {
-			if b.board[row][col] != player {
-				win = false
-				break
+	return int(b.winner) == player
+}
+
+// inRow checks if the cell at row and col is part of k cells in a row of
+// player along any direction
+func (b *Board) inRow(row, col, player int) bool {
+	for _, d := range directions {
+		count := 1
+		for _, sign := range []int{1, -1} {
+			r, c := row+sign*d[0], col+sign*d[1]
+			for r >= 0 && r < b.size && c >= 0 && c < b.size && b.board[r][c] == player {
+				count++
+				r, c = r+sign*d[0], c+sign*d[1]
 			}
 		}
-		if win {
+
+		if count >= b.k {
 			return true
 		}
 	}
-	// Check diagonals
-	win := true
-	for i := 0; i < b.size; i++ {
-		if b.board[i][i] != player {
-			win = false
-			break
-		}
-	}
-	if win {
-		return true
-	}
-	win = true
-	for i := 0; i < b.size; i++ {
-		if b.board[i][b.size-1-i] != player {
-			win = false
-			break
-		}
-	}
-	return win
+	return false
 }
 
 func (b *Board) isBoardFull() bool {
-	for _, row := range b.board {
-		for _, cell := range row {
-			if cell == int(EMPTY) {
-				return false
-			}
-		}
-	}
-	return true
+	return b.filled == b.size*b.size
 }
 
 // Implementing Action interface for Move
@@ -0,0 +1,102 @@
+package main
+
+import "testing"
+
+func TestBoardSizes(t *testing.T) {
+	tests := []struct {
+		name    string
+		size, k int
+		player  []Move
+		other   []Move
+		winner  Player
+	}{
+		{
+			name: "15x15 row inside the board",
+			size: 15, k: 5,
+			player: []Move{{7, 5}, {7, 6}, {7, 7}, {7, 8}, {7, 9}},
+			other:  []Move{{0, 0}, {14, 14}, {3, 3}, {10, 2}},
+			winner: PLAYER,
+		},
+		{
+			name: "15x15 anti-diagonal",
+			size: 15, k: 5,
+			player: []Move{{3, 10}, {4, 9}, {5, 8}, {6, 7}, {7, 6}},
+			other:  []Move{{0, 0}, {14, 14}, {12, 1}, {9, 9}},
+			winner: PLAYER,
+		},
+		{
+			name: "15x15 k-1 in a row",
+			size: 15, k: 5,
+			player: []Move{{7, 5}, {7, 6}, {7, 7}, {7, 8}},
+			other:  []Move{{0, 0}, {14, 14}, {3, 12}},
+			winner: EMPTY,
+		},
+		{
+			name: "15x15 row wrapping to the next line",
+			size: 15, k: 5,
+			player: []Move{{0, 13}, {0, 14}, {1, 0}, {1, 1}, {1, 2}},
+			other:  []Move{{14, 14}},
+			winner: EMPTY,
+		},
+		{
+			name: "4x4 anti-diagonal",
+			size: 4, k: 3,
+			player: []Move{{1, 3}, {2, 2}, {3, 1}},
+			other:  []Move{{0, 0}, {3, 3}},
+			winner: PLAYER,
+		},
+		{
+			name: "4x4 column",
+			size: 4, k: 3,
+			player: []Move{{0, 0}, {3, 3}},
+			other:  []Move{{1, 2}, {2, 2}, {3, 2}},
+			winner: OPPONENT,
+		},
+		{
+			name: "4x4 k-1 in a row",
+			size: 4, k: 3,
+			player: []Move{{0, 1}, {0, 2}},
+			other:  []Move{{3, 0}},
+			winner: EMPTY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := NewBoard(tt.size, tt.k)
+			for i := 0; i < max(len(tt.player), len(tt.other)); i++ {
+				if i < len(tt.player) {
+					board.Exec(PLAYER, tt.player[i])
+				}
+				if i < len(tt.other) {
+					board.Exec(OPPONENT, tt.other[i])
+				}
+			}
+
+			if board.winner != tt.winner {
+				t.Errorf("got winner %d, want %d", board.winner, tt.winner)
+			}
+			if eog := board.IsEOG(); eog != (tt.winner != EMPTY) {
+				t.Errorf("got end of game %v with winner %d", eog, tt.winner)
+			}
+
+			// the evaluation favours the player with the longer line, the
+			// winner scores a full win
+			mine, theirs := board.Eval(PLAYER), board.Eval(OPPONENT)
+			switch tt.winner {
+			case PLAYER:
+				if mine != 1 || theirs != -1 {
+					t.Errorf("got evals %v, %v, want 1, -1", mine, theirs)
+				}
+			case OPPONENT:
+				if mine != -1 || theirs != 1 {
+					t.Errorf("got evals %v, %v, want -1, 1", mine, theirs)
+				}
+			default:
+				if mine <= 0 || theirs >= 0 {
+					t.Errorf("got evals %v, %v, want positive for the longer line and negative for the other", mine, theirs)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mendel/codingames/mcts"
+)
+
+// bookRecord is the size of an encoded book entry: the big-endian hash of
+// the position followed by the cell of the reply, Row*9+Col
+const bookRecord = 9
+
+// Book maps the hash of an opening position with us to move to our reply
+type Book map[uint64]Move
+
+// book is decoded from the generated bookData once at startup
+var book = decodeBook(bookData)
+
+func decodeBook(data string) Book {
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ERROR: Invalid opening book:", err)
+		return Book{}
+	}
+
+	b := make(Book, len(raw)/bookRecord)
+	for ; len(raw) >= bookRecord; raw = raw[bookRecord:] {
+		cell := int(raw[8])
+		b[binary.BigEndian.Uint64(raw)] = Move{Row: cell / (SIZE * SIZE), Col: cell % (SIZE * SIZE)}
+	}
+	return b
+}
+
+// Lookup returns the book reply of state. A reply that is not legal, from a
+// hash collision or a book generated for other rules, counts as out of book.
+func (b Book) Lookup(state State) (Move, bool) {
+	hasher, ok := state.(mcts.Hasher)
+	if !ok {
+		return Move{}, false
+	}
+
+	move, ok := b[hasher.Hash()]
+	if !ok {
+		return Move{}, false
+	}
+
+	for _, action := range state.Actions() {
+		if action == move {
+			return move, true
+		}
+	}
+	return Move{}, false
+}
+
+// generateBook searches every opening position up to depth of our moves
+// for moveTime with config, playing first and second. Our moves follow the
+// book, every reply of the opponent is explored.
+func generateBook(config Config, depth int, moveTime time.Duration) Book {
+	b := Book{}
+
+	var visit func(state State, ours bool, depth int)
+	visit = func(state State, ours bool, depth int) {
+		if depth == 0 || state.IsEOG() {
+			return
+		}
+
+		if !ours {
+			for _, action := range state.Actions() {
+				child := state.Clone()
+				child.Exec(OPPONENT, action)
+				visit(child, true, depth)
+			}
+			return
+		}
+
+		hash := state.(mcts.Hasher).Hash()
+		move, ok := b[hash]
+		if !ok {
+			ctx, cancel := context.WithTimeout(context.Background(), moveTime)
+			action, found := NewTree(state, config).Search(ctx)
+			cancel()
+			if !found {
+				return
+			}
+
+			move = action.(Move)
+			b[hash] = move
+			fmt.Fprintln(os.Stderr, "BOOK: position", len(b), "reply", move)
+		}
+
+		child := state.Clone()
+		child.Exec(PLAYER, move)
+		visit(child, false, depth-1)
+	}
+
+	visit(NewBitBoard(), true, depth)
+	visit(NewBitBoard(), false, depth)
+
+	return b
+}
+
+// writeBook writes b as the Go source declaring bookData
+func writeBook(w io.Writer, b Book) error {
+	hashes := make([]uint64, 0, len(b))
+	for hash := range b {
+		hashes = append(hashes, hash)
+	}
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i] < hashes[j] })
+
+	raw := make([]byte, 0, len(b)*bookRecord)
+	for _, hash := range hashes {
+		raw = binary.BigEndian.AppendUint64(raw, hash)
+		raw = append(raw, byte(b[hash].Row*SIZE*SIZE+b[hash].Col))
+	}
+	data := base64.StdEncoding.EncodeToString(raw)
+
+	const width = 72
+	lines := make([]string, 0, len(data)/width+1)
+	for ; len(data) > width; data = data[width:] {
+		lines = append(lines, data[:width])
+	}
+	lines = append(lines, data)
+
+	_, err := fmt.Fprintf(w, "// Code generated by tictactoe -gen-book; DO NOT EDIT.\n\npackage main\n\n// bookData holds %d book entries\nconst bookData = \"\" +\n\t\"%s\"\n",
+		len(b), strings.Join(lines, "\" +\n\t\""))
+	return err
+}
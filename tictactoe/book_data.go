@@ -0,0 +1,139 @@
+// Code generated by tictactoe -gen-book; DO NOT EDIT.
+
+package main
+
+// bookData holds 794 book entries
+const bookData = "" +
+	"ABROckApP1pGAEvVxq+p5tlGAExifj2oal00AISI44x3Zm8IAZYghLnU8e4XAZbd7ANdFx0z" +
+	"AfKcWfzD0JcOAfhH5tLjv68PAgIaVw1Fk5ELAg1bl9vFvvU4AhRJ80BvZHMKAkQhU9+B02E1" +
+	"AkQza+wLARMZAvMfISGlh+o1A55jqVv9SNIWA+/yTNV5kCs5A/NIMT3rE/AOBBye+V1LElgG" +
+	"BLFmHr9DJAUcBLap91Pz++4LBWqKQy3/pKAXBW6u0wpd+WQoBXcAa76bJ/VLBYSY3OSIJHBG" +
+	"BaF25WvcYKwyBbTarUvo7J4oBfEkmVbNVxAiBh1KkZxj0T8aBotdN7lKk0NKB/1OdcnkRE5H" +
+	"CLNw8TpV/hIHCXuC7+Dma38XCelR+/ZZjj43CmmxtYF7IZZACpulBvgxvYMICwqzOzdI9yg5" +
+	"C0z5px2kZwMbC5VFAkvq5KEWC7z6vAC0qkoeC9OuldYk5UIWDF/V0v/Uh2MwDJZB7Ra9h7Ad" +
+	"DKeEaILN0ohQDLI8bAVPpglNDLT8FApATtdCDPikP/hMG3gDDeU3FLrDZOZBDlJNH8KLy541" +
+	"Dlyrv5ey4kASDp6s3s4h/B0SD+aEL8NkexIIEJ6FJCaBcZovENeZSSEKRqQSESN3ODG6yrgS" +
+	"EVHUWp60EY0uEWib3vVuiV4hEfhf1p04R3pIEyPT2pFZBLUhE4w0H2lCj0coFGviz6NycZ0v" +
+	"FMQviCdcbKgVFNfKTIlUi65NFbEuFnPWa/kzFf3ecm/KD6oGFf9ytMmLQpY2FhrIlgs+AQw2" +
+	"FhtwuGJYalM3FvbfZASmQf44FxqNlDW20aNPFyaSRPuiCudGFysBNhhFhzUAFzOu3uBcGCMA" +
+	"FzpJKSWyR5UvF2RIJCgXKTAFF3et4IYfzjZNF67wKJ6I8o47F/k0WQg6S0tAGASj77MifvU8" +
+	"GLODM0M4pXdGGLfeyGKLKtEiGNvPUS94WTwuGQdFHMNcYJkDGS2zKrJBAmcRGX4CMrR2RME+" +
+	"Ge++Yg+ObsZKGg8UL+yHS+JFGqGLBxqc0b0oGx1xatCorqsYG3j+85LbPClGG3/WWnVeh6Y9" +
+	"G73C6iDsFTUmHDarmBDB1O9IHDnqWMZB+YsAHD8+/5L60oFJHGHdxjusmF9CHIYr/p1nds4w" +
+	"HMWzLvjNBiwMHQ0vl5vvmxhGHZhtH6i++1sbHeYUKGVKMPZGHtj1TJ04F7kmHwFFewTGiEYi" +
+	"H16qGlMXGCwdH7ohaVkniKYLH92pSwVEJuM7IAIZTTtJ3hIyIF7VkYSkOy5IIiRWKjze1/U0" +
+	"IjHNXjEfmQ8ZIjcNJj4QcdEZIsmVABnxacFGI/4U0LiiTEYVJF+VydBJ+PRNJHxfpOcFgllN" +
+	"JMa5Qlq3xZAeJNAli3/kVpUBJN9kS6lke/FAJOePRjci8XVAJOjOhuGi3BEAJY+CcQwXIPAa" +
+	"JeOelmY2ZhtGJgtKmtIN3U4JJi7ZTtrUPBZEJkP5Oml9I4NPJkgK7YrOETpIJku+CZo6hTJH" +
+	"Jkyd5IXsLpVNJpBCnSMIPX8EJ5IiOJy+2PsdJ79QeIqKCS5FKKwy/DqOj5Q7KOMTSuUcgQoQ" +
+	"KTD3NVxAk0A7Kd3Yr6lMz3YtKig4JL9IrRsbKmXmpjBv1JIUKoN1Xf0DMd4WKpSWjD4pbSsK" +
+	"Kpghwq3/1IoZK5JSwa9reApALWfdHkcHBGgLLYCLzCbN5/EiLapf0kNiLHZLLazF6tP/cBg2" +
+	"LcsXSkAogDIkLuK2VYQrfxMeL3YjYgx3FkUEMBuqjFWF2ZlFMFxKd22M0WsmMJWHRGJkC8QT" +
+	"MbhfR0oxUchJMeQAS2g0Mu8oMl2eH6ykEgwtMnKzy6ccl0oQMpAIveAFfxUNMrdooZYg+yxE" +
+	"MrmDfPsepQkuMy8f1tdkAydPM+xDZoodi9MoNFcs4+jDV98yNIO0fHdGR3goNMRcbbywpVwv" +
+	"NMbM5l/BodYsNPnsCCiFC6syNQV5B9t0fqY5NRyOHNu9OmUNNXyAwuvvK/lQNZ/9kU02g/Av" +
+	"Nc7qcWyj5ERQNsb95zK7GEZNNyMc9wQpqVMTNziQ2nWOAO1AN/q9CUTCghM8OBL0K8vUHUtK" +
+	"ONd0WTB7ZdI2OSvBYqXXJVYZOT96+7DRtlAhOYCtqgIBRWMtOrrYUf0Wk3Y2OvwzxmDYkpVA" +
+	"OwDHcapJV6IOOzUgL6fQSqIoPAyvhxdC/AooPIxB0JHxEtIMPZHS+9N+bUxBPaQKJSYkfZZG" +
+	"Pads4WHtwmcsPa6+XPO1qc8xPp8A7xE/mfdDPre1B1cYSTozPr4ewoA9EBUQP0NbdkeoxSYq" +
+	"P5i7IEgValcKP6a4iaDFAQAhQMkvw8BNJQsOQNw8R/wE3q8zQQZ+vXh4RRwXQRNtOUQxvrgi" +
+	"QR1/FVU4kmkuQS2EO2AI+hpCQYTa05PPUnNCQYeXJ4xhCNglQfjJFOB4dScQQgLxs0jDbwY7" +
+	"Ql4jM+3l7ik7Qn5dAI8cA189Q6uTHxUbphUAQ8Dzu83e2vEgRAA9YbB18BscREx1GvZmn4Eg" +
+	"RGPsYBXZIBUMRSWhFiXu6V9LRY53EnN7KnZLReA2i6cMveIiRjEMlOosqk8tRkHZh9pfqvRK" +
+	"Rnj5bhOnL3woRqKysHtLcxMMRzPqJ26q4u4rR3jwSv836KAzR58rrbLQrY8AR7ZNt9yNOQ5L" +
+	"SIEF1jREAtkASJK9yewERn8KSKt+c0+Co+IGSTlVeqengDIUSVcpcGua+/cQScSXf1XXS5VG" +
+	"Sjw6pIGvUU8QSu0wkyvV8QMiS4SyShzB3rtKS7VeY3Sqw4gOS+bXWo4wDFcoTCRCt/itoPoo" +
+	"TCl+aGsdYLk1TE2/kpRXuhBCTHVUnwoRMJQ5TLkr0MoSOTgXTMIP0Dv6FHEATM1OEO16ORVA" +
+	"TNTR6wDpLx0YTShYo1co0+UHTTYg9VghK5VQTbP97cUFC2kVThD2kwjvFBYhTjGs3OMd3WxO" +
+	"TubWH9zcTw45Tz7rBqVSar0IT1eXCF/oZcIMT3S2Rw+iaoUQT4B02WHqXdgaT8TQG8aF99Qr" +
+	"T8afTvwkXDsQT+l6WbiJbJIaUA7bHectxWgdUMsO7a6dkPw7UQyn7ZbP+OUvURVPciG7wDxN" +
+	"URsFq3L18/E5UWtFWu80KOYoUa4EPtgEtzAEUgaYMAUEfFQlUhE7neb3PQs8UiWZPjxg1LUL" +
+	"Us2WKbIlwKYIVDW1u4KnrW4+VHYorT/Yv5JKVRFAcez8Xw0UVSGMcmMt/zdKVYR/CintYa8o" +
+	"VZ0WaQ5nhZQTVbyUB7er6ysoVcor+5UD550hVhnegtB9eCoQVuFoPKKN7X1GV1VI6waEgFAM" +
+	"WJZpYk8DvrYHWXSfsz566i5GWXkMwd2dZ/wKWdzw9n8n9VwyWqv8uQ0ploYFWvHIQgeK/hRG" +
+	"WvLDa8eplwkNW3eC9gRnvuszW3f2xd5q144kXAKQxgVoCc9AXAV87UfTR8QoXHXNfakCB3Qc" +
+	"XNT4jsw4KIU0XQ66dEhEszYEXX5pZgWPuv0FXxEdiqrwFXswXxzBy2YhVcYIX015cFZaa2gi" +
+	"X06rJmdmFjEOX5J0X8GCBds6X+NeaAEoUHwkYDFnW9+/yaQvYL6+ItIgAt5AYMdy+vM2wQRA" +
+	"YNMwqyQQ03olYWRPO1O2eyYeYcWJKs8PRbgvYsOY0ZTGheUPY4kBRaTizg8wY56UO1tKlCI0" +
+	"ZC55NdYk/PErZDM5miNYuh1IZIR1hYVEDuMuZUZIehsDgKUhZWv+3+sGPwNIZXnyrE13coEC" +
+	"ZZT/+Q+J90ogZceF7BpHPohEZqcz01qHhfk0ZwZ/9oqmxf0zZ/g6AQp4LUAlaO6iAE6xZPNG" +
+	"aYJTl7AZAi8oakHTG3gDttAWannikdPkNhxAap+ATub2fxRLaweebUg0f5pDa1wVRVbmlvUv" +
+	"a5LcVeJbDBoBa9ahoAfT3e4ZbBcfoi69zMk3bD9RIHO5JZcrbVVb1A4ORDUobXyau5mkcDcw" +
+	"bcFtxuHjZz1Abcetvu7sj+NAbeXbsoOo5hRAbeqaclUoy3ABbifd4kxUYAVKbktujtBu/SIz" +
+	"b1DtL3O53QxGb1YtV3y2NdJQb6i1cVtXLcIQcHm0LQJsU9tCcLsPDBGt5goycOzbaFc8K8gq" +
+	"cTPsktX9N/MYcXRecqmYhh0ecb1ZjushiGEAcmbvSeZbeMAvcpdzwKlg3AVCctGYVzSu3eY7" +
+	"cwZZkK5qZ28cc26r28UKLi8ec7QWVKRj01swc/uUoAAbEI0UdErhE+dxYVVPdJ8c/ooLLWEe" +
+	"dKRwQS3yFQ46dMNaLr6b6Z9GdNn3aRfFLIIwdN8Pz53UHh4Ada2sNHkiDnxAdfNPDdB0RKJN" +
+	"dfqS/RrGI9xLdgrZocJH7H0VdhHYuUfKsMA/doAUQFbnWl4QdtCSf6qrsBIwdt2hgnokM7pA" +
+	"d76BxQDFTwsWd9CbW0Y7d84jeJzvyOsir/JDeN+bnCY/qkgQedhxeGiP8PkKel7pxPOhZB8C" +
+	"engW1GfEtWEKetwop1+YtbxAeu3EjjfzqI8Xe2WzB3wB15UVe44/jEtJj5Qve4/Ew7ni6f8r" +
+	"e5krU3zrNvk0fHoEPYOLG9A1fLXqE0MxnSMDfZG+dfgvQJUdfdfQitsC9yEMfi3C6VNpmKUQ" +
+	"fk4/p5XCRyQaflqEPoDE1CIhfnec8L7UVy0ofze7ImUpSyZGf1MdPR2H7zgQf19beoOgE8dD" +
+	"f6MI9DCirKYQf9Bak5IFGnpKf/p0+EeECfVGgAFPeP38RPZAgCVjCsOjVuBGgFDwJ1wrooAo" +
+	"gLXJdTomVbAKgRJABArAwoYugRyr2Wf+nKNDgbdP6Apx/rMmgg/O0K33rQMFgjcl3TOxJ4cX" +
+	"gvf7jZ2fmF8NgvtakvOyLis5g0WISkQtGMwxg9spUipBFiAghKjIq4XTUFU+haZF2tsJJudG" +
+	"hbg9jNQA3pcRhrPxu4l8EO00hvT6LpHCjE0Vhw5poO3LqNpQhzlP1AGeEMAKh0iCN3AFqTk8" +
+	"h00avjpGG6Igh1IvcNlbbCQeh3ayXcWENtUKh9knfZI55UgQh+HMcAx/b8wPh+6g7po6hgYO" +
+	"iBS+kYqXK6gAiMgxM/syEFVAiOR/FQ4Ah7wjiRB4hkAq+XwoiSYCNyLh+U1AiaaJ+QjkwLdI" +
+	"ianIOd5k7dMAicLkxEEjYaoLiiWFD57hfMlLikw3i7d4NI0jinQnHJfTKTEbiobDdh7njiUV" +
+	"iq058AaXMSM7irAfKk9hvOQoiyCJQPILCdYOi0jXLYUdA+Eci1yBjJqbFZYzi2Jojr6iUTRE" +
+	"i7FCpr+0q6I9jGsBpiBEjE4gjIfg3Yz0uE41jJYS85RGq0EWjQn1/QWkByxEjUGZvN310SEo" +
+	"jZ1M/L6esjcvjolugmXnBro0jpGr7Mews/85jr+VDpZxXqgKjsBIv9m6HMYuj7FkEOyLiDM/" +
+	"j9fmmveHerUMkBh0G8MiS7RGkPS6QNLtUdQ4kTPR0iTdk/QdkTkP7xdet5EvkYuC3JiiErcF" +
+	"kZiQS8VhRUxQkcKksM/CLd4DkdDooqAA1oAVkjgAtDnhsClLkxYhwdljljsakySvpof3T5oI" +
+	"k1Td7N2mFEw4k18uOz4VJvVGlKrCm/4U920KlLCyKf4JgBo5lVz2Bycs/s8AlXMnIk4Zafsv" +
+	"ldsv2saBnQBElem1QRkMFz8ulmitsmfCP54EloGAuCVZ5rAvlodAwCpWDm4tlo34ym+Ss2g6" +
+	"lqKBthw9TlEKlqX9OTarxwlElsPgyyWJo1slltLTGtdoBnoMl7aaPOc2U29Al9l3AXvlu/Md" +
+	"mDpJnYjL5RJAmIUrMDjjuecUmIgBtEJ4evc1mNloBA6BmugrmUF4IcWOnLAlmaTOIHDOe/4Y" +
+	"md3OsKMdDA0cmgvhiZpXMoJBmmGyLAhb1D0Imp8m5GrWyAkGmroLWlQOf3Azmru7tnsEs9E+" +
+	"mwkaWaZutj4umwpxB9BOfxAom10/XxKOCgEom2BJoNBy5MMDm/8P+Irnvp5InSVMmbNnP20C" +
+	"nVPYatyr3YFGnVpO1BYr6u8inbwxvze6szsrnlHdHmvOjokunqj3H8yXu+gYn384Hlo78YI0" +
+	"n7SJOb0nZTEhn8SY+0H+j25Kn/JiriDpZNIroCBsA5x9POYKoECCDSdS5wZGoLmeZosyoLRK" +
+	"oNmwaHbcXw8SoTPzrH7eqsgGodj4iXA0ad4Eog2pQoFt5mw4omqfaFznlktGomt64dF3y0A5" +
+	"oqXfpuEuLos0owxUbtaDXZw8o2XE5Ug04bMSo4zEtz770PQIo7VhGP/sXzYLo7og2ClsclI2" +
+	"o/AxOEABZLMRpCgIuj3I5vsIpN3xDN9WaAcWpO97ACnjcLVOpSPiqjl4xQJApSyjau/46GYK" +
+	"pTP8cTEN/0I3pedZxIX9huwQpoNVAEtuWrEopoU0uLPaxW0NpyI2wvhQBmVIpy13Ai7QKwET" +
+	"p3C9KmiiBD4Lp7ul3vxacE5Kp87Kaj+SSDc7qC8YFWvd/tk6qIS6Hv/W/i0cqKwbRD2OwBYv" +
+	"qK2N62c8k0IiqUZ1TsG+/dlJqdl28r7CFgpQqk2FqBjncOdPqpZ5PKHVyKoXqxBvyQNmfqob" +
+	"qxJpbwGSJ8IoqyTdsvCdu7Ucq042YyOXROU4q2KXTbFlRLYOq9E131zrrzZFrIMI+w1iEMcK" +
+	"rM11E292TkESrROHOQf5STo2rUmAz5MtBh8MrvX0Qq3FuKMFryELSlyE1jIirzbF0/tiPh8b" +
+	"sCBLBtuF5rMCsEra6UmZqYEbsFGG5aoeX0pGsFbf9bRJBF9GsGagkUZL51ASsQdYhnvI0rxI" +
+	"sfoffhuZihgoskZr8yVxNKQosrGOptXFvO80ss6M63CJaW0Ust0+rKx1gGowsu3qfxGxQH4z" +
+	"swWklbhC+t5GszeohZXN280Xs2vIfiDPcaQXs5nz86L+Jnsus6EY/jy4rP8ltdgYP1MscZsr" +
+	"tgr2uLx6Z5UItmRssm3Ezycqtr6IgxnpTHkMt02qO2gjGWoTt1ciU5p1pAowt3Rpgcul/PcP" +
+	"t90ARJGmGjpPt/jF9SnehTNEuCDMcw30ENEIuC09zPJ58bpCuEkMKBzhC1weuGqBngHmOu0I" +
+	"uOnRAoFEXqlCuZSlR4rsxapBuaFH99AAZmEXukn/hNk5f+I4uuYBAxRSAh0DuvTjuc8HTSQo" +
+	"vE1HVzK6HJ0PvFFvZ5fj/FFPvTQeurS5AP4RvaGe7LyomEJCvf9kN7903XVJvlo+aDDZv/Mh" +
+	"vmLVZa6fNXc1vwgDo7XawNIav7ZXxl8/pUYAwKH0dkmngxcWwKNxNTKKXsU5wTJbZTWA5a8t" +
+	"wVjKiqecqp0TwXSw8qhO5EwuwXu2jM3bGKQiwdEr4yCl0hAVweNp5ylTaPo2wevnwGPOZg0M" +
+	"wmKpi4HmcI5DwoL9Aui+olctw9yciJ6ManEdxFvjbs6gwaEMxMTHyXqUyPBKxNsSBHj12uIV" +
+	"xY/IBS7JNqAsxggbo1NDkkUKxiG/udfoV7YAxl+6WIYmGnYuxsM52//cSYUrxsX5o/DToVsi" +
+	"xtBi1/0S76EYxu31nIwvrkIxxw7InNd5hcZLxxOOkmCTiCg8xxl7IXug0jYWxx+7WXSvOugN" +
+	"x2iZVk9FjhBGx516QWccDLE8yDJYaw+EtbUByDUk5CUSPO1LyGzdx2C+l+8hyMXIvyOIE9sG" +
+	"yV+lXfNCO200yXfr365G0jNAyYg2TvAnLxFGyjsbE7BcFJoQyj1nycc7hdFEysd+RSbeLbU8" +
+	"yvdWLqPsPU4AyviyOY6L0EUcyyj7ARRfs7NMyznvooGz3NwXy29nHZGrl6EqzMSmeT/n9Y8U" +
+	"zP+Ng3ODaWE5zbE5cwRLBONCzdg0fU88ObMIzf7L/Il19BYozttziSaz8ikQztwehXR7yZgo" +
+	"zwKjDbGtoU4Cz3NTDEXFsUwZz7DtG8Vjx4Q50AnIEMKyT5Uc0DL4G54NmYIG0RWB/fjCix0o" +
+	"0SQdTJeDjdJQ0aOuUz5g92VQ0mChj1PvfBJN0oEJVmCmvuAG0tnQlDej4ogo0tpjLqQk8C0Y" +
+	"05NsE1yJW6k+05RP/kNf8A5L07nvQs+OpLBO1C4lJu1KjJUg1DgXWKL8Y7xL1Dqcoj+4Q58C" +
+	"1EAS5ie3Vdgf1YMy7hfObhoI1YrTYwxcrmMl1kHGjuaflr8g1kgUM3TH/Rch1mKtSYanByIv" +
+	"2DadSZ6HfeUo2GiNtfBBVuUg2SrUpKT3uW0b2x4470AeuqMt26h6AzPHTpMM27MypXim7hsc" +
+	"3AW3ZeBnhrEF3EqK9Lj0rWYr3NLPRlgEWXY43d/1n2QbHQIi3kWP18VRC5k83sz8qN0YcJMO" +
+	"35SSWIJnQhgs4BBJlnjhYGc54DNYotp0bbEV4TRSIDXXAVY74YsCT+mXz5QR4q9THMs070st" +
+	"4zY7voWH6v4C40kGthQDHSwQ46PL/tmiwvkW5LhWg/xkUgkQ5PulIvcJFCMS5mq3pEo/FQQk" +
+	"5n0V4q4FHhBE5rAVrh5N6zUI5stnYpXguO8C56NwDdvLN+oq6FVTncZNOHk56Hzv5BasvdEe" +
+	"6IZ5tcdbwu9Q6MF8wZTYKpcz6NLeYEKK7iQ56Sw8zJPrDtxG6TGx6Q1JHu086Ukoy39uovhG" +
+	"6WCmCSzceVkt6Z5hcYtHzlkg6hE5JFoOkAA26hsKOkhrtSIK6h3ki+np7+cO6mbAixgBwq4K" +
+	"6mmBS86B78pA695oWuI78BdI6+fmyoVlB+0q7Ddc+l1iPHEu7HeXqjGHRbAo7HqkV+EIxhg2" +
+	"7LXm9BX6RIQd7TpaYG4LjKg87WPFrlNxhKot7amvGS+KgAwo7h8ciAFtih0K7oYu7VDjMhRQ" +
+	"7pxayfwvHqMg7sWjcBYbcDAP7swItcE+KR817u3kxnxdlPdG7zFNAQar/CwG72SX7uU4DjRA" +
+	"76WKbPYqBqdB78Xhi83Q/G4o79Dl2DXiPt5L7/PmK0hhLSgR8ABQZ+TFwZoA8IKRBEj8EDk3" +
+	"8NPUuZhHQBYo8WEgg68KBdg88afZFtQjVqwt8iK/ghCBMXI68pF64KFce7FG8yezzXxrRG0D" +
+	"81b9UW6EnTtG83Z+OkVkcGkW876N6Wblql8K89qgmMhpY2Ig9Gw7K64kwfUw9S+RPNUvCNwv" +
+	"9UHsAk9SVNI99ZQkMXbMk+VN9fZY5tPUIq859gjfYlFNE4xD9itft++bE9wd9jADuwwc5Rc+" +
+	"9mvNFF/Dl4IA9t+loW6Mge4892CBkRoVIhYi92tb7qpAETco922blqVP+eko99ask9IHBj1J" +
+	"+B6dG4V0pAcV+ECo92j/Yp4O+FDpYCasuNg0+Fj7T3mih1wI+KLAUhp1GjUi+MV5tRYrUG0g" +
+	"+TWpaTx1Zc8r+b2pbSGfnJNG+mhhRljKb/MQ+pXOxTM+IFci+punBxZ0MRok+3TzQigRo88K" +
+	"+/Mqn8m8wAA5/CJp8MM6Qfs4/C0oMBW6bJ8L/C3RBCW54jlA/FHI07dlu7U4/IDHE1LA4bYw" +
+	"/JfcLNdxjuYT/Vo6EJ59l8UA/fKdu2fO3vsI/q4+SqWbCpkN/rfJUaVSTlpM/uENbgxuuIwU" +
+	"/yK6KNj8MIo2/3R8sCHnkSoi"
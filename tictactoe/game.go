@@ -5,49 +5,92 @@ import (
 	"os"
 )
 
+// Owner of a sub-board: the player who won it, DRAWN when it is full
+// without a winner, or OPEN while it can still be played
+type Owner int
+
+const (
+	OPEN  Owner = Owner(EMPTY)
+	DRAWN Owner = 3
+)
+
+// SubBoard is a small board of the game
+type SubBoard interface {
+	State
+	Owner() Owner
+}
+
 type Game struct {
-	games  [][]State
+	games  [][]SubBoard
 	player Player
+
+	// last move played, its cell in the sub-board is the sub-board the next
+	// player is sent to. It is {-1, -1} before the first move.
+	last Move
+
+	// hash is the Zobrist hash of the cells, the player to move and the
+	// target sub-board, updated by Exec
+	hash uint64
 }
 
-func NewGame(gameSize int, games ...State) *Game {
-	gg := make([][]State, gameSize)
+func NewGame(gameSize int, games ...SubBoard) *Game {
+	gg := make([][]SubBoard, gameSize)
 	for i := range gg {
-		gg[i] = make([]State, gameSize)
+		gg[i] = make([]SubBoard, gameSize)
 
 		for j := range gg[i] {
-			gg[i][j] = games[j]
+			gg[i][j] = games[i*gameSize+j]
 		}
 	}
 
 	return &Game{
-		games: gg,
+		games:  gg,
+		player: PLAYER,
+		last:   Move{Row: -1, Col: -1},
+		hash:   zobrist(zobristPlayer, int(PLAYER)) ^ zobrist(zobristTarget, 0),
 	}
 }
 
 // Clone creates a deep copy of the game state
 func (g *Game) Clone() State {
-	gg := make([][]State, len(g.games))
+	gg := make([][]SubBoard, len(g.games))
 	for i := range gg {
-		gg[i] = make([]State, len(g.games))
-		copy(gg[i], g.games[i])
+		gg[i] = make([]SubBoard, len(g.games))
+
+		for j, game := range g.games[i] {
+			gg[i][j] = game.Clone().(SubBoard)
+		}
 	}
 
 	return &Game{
-		games: gg,
+		games:  gg,
+		player: g.player,
+		last:   g.last,
+		hash:   g.hash,
 	}
 }
 
-// Actions returns a list of possible moves from the current state
+// Actions returns a list of possible moves from the current state. The
+// cell of the last move dictates the sub-board to play in, unless that
+// sub-board is already won or full, which frees the choice. There are none
+// once the game is over.
 func (g *Game) Actions() []Action {
+	if g.IsEOG() {
+		return nil
+	}
+
 	var actions []Action
-	for i, col := range g.games {
-		for j, game := range col {
+	for row, r := range g.games {
+		for col, game := range r {
+			if game.IsEOG() || !g.isTarget(row, col) {
+				continue
+			}
+
 			for _, action := range game.Actions() {
 				move := action.(Move)
 
-				move.Row = move.Row + j*len(g.games)
-				move.Col = move.Col + i*len(g.games)
+				move.Row = move.Row + row*SIZE
+				move.Col = move.Col + col*SIZE
 
 				actions = append(actions, move)
 			}
@@ -57,6 +100,27 @@ func (g *Game) Actions() []Action {
 	return actions
 }
 
+// isTarget checks if the sub-board at row and col may be played in
+func (g *Game) isTarget(row, col int) bool {
+	target := g.target()
+	return target < 0 || target == row*len(g.games)+col
+}
+
+// target returns the index of the sub-board the next move must be played
+// in, -1 when the choice is free
+func (g *Game) target() int {
+	if g.last.Row < 0 || g.last.Col < 0 {
+		return -1
+	}
+
+	targetRow, targetCol := g.last.Row%SIZE, g.last.Col%SIZE
+	if g.games[targetRow][targetCol].IsEOG() {
+		return -1
+	}
+
+	return targetRow*len(g.games) + targetCol
+}
+
 // Exec applies a move to the game state
 func (g *Game) Exec(p Player, action Action) {
 	if action == nil {
@@ -105,28 +169,68 @@ func (g *Game) Exec(p Player, action Action) {
 		return
 	}
 
+	// Ensure the move is played in the sub-game the last move sent us to
+	if !g.isTarget(subGameRow, subGameCol) {
+		fmt.Fprintf(os.Stderr, "ERROR: Sub-game [%d, %d] is not playable after move [%d, %d]\n", subGameRow, subGameCol, g.last.Row, g.last.Col)
+		return
+	}
+
 	// Apply the move to the sub-game
+	target := g.target()
 	subGame.Exec(p, subMove)
+	g.last = move
+
+	// Update the hash with the cell, the target and the player to move
+	g.hash ^= zobrist(int(p), move.Row*len(g.games)*SIZE+move.Col)
+	g.hash ^= zobrist(zobristTarget, target+1) ^ zobrist(zobristTarget, g.target()+1)
 
 	// Switch the player
-	g.player = 3 - g.player
+	g.hash ^= zobrist(zobristPlayer, int(g.player)) ^ zobrist(zobristPlayer, int(3-p))
+	g.player = 3 - p
 }
 
 // IsEOG checks if the game is over
 func (g *Game) IsEOG() bool {
-
-	return g.checkWin(int(PLAYER)) || g.checkWin(int(OPPONENT)) || g.isGamesFull()
+	return g.Winner() != OPEN || g.isGamesFull()
 }
 
 // Eval evaluates the game state and returns the result from the perspective of the given player
 func (g *Game) Eval(player Player) Result {
+	switch g.Winner() {
+	case Owner(player):
+		return 1.0
+	case Owner(3 - player):
+		return -1.0
+	case DRAWN:
+		return 0
+	}
+
 	var eval Result
 	for _, col := range g.games {
 		for _, game := range col {
 			eval += game.Eval(player)
 		}
 	}
-	return Result(eval)
+	return eval / Result(len(g.games)*len(g.games))
+}
+
+// Empties returns the number of empty cells in the sub-boards still open
+func (g *Game) Empties() int {
+	empties := 0
+	for _, row := range g.games {
+		for _, game := range row {
+			if game.Owner() == OPEN {
+				empties += len(game.Actions())
+			}
+		}
+	}
+	return empties
+}
+
+// Hash returns the Zobrist hash of the position, equal to the hash of the
+// same position as a BitBoard
+func (g *Game) Hash() uint64 {
+	return g.hash
 }
 
 // Player returns the current player
@@ -134,51 +238,65 @@ func (g *Game) Player() Player {
 	return g.player
 }
 
-// Helper methods
-
-func (g *Game) checkWin(player int) bool {
-	// Check diagonals
-	win := true
-	i, j := 0, 0
-	for i < len(g.games) && j < len(g.games) {
-		if g.games[i][j].Eval(Player(player)) != 1 || g.games[j][i].Eval(Player(player)) != 1 {
-			win = false
+// Winner returns the owner of the macro board: the player with a line of
+// won sub-boards, or once every sub-board is closed the player who won the
+// most of them. It is DRAWN on a tie and OPEN while the game goes on.
+func (g *Game) Winner() Owner {
+	for _, player := range []Player{PLAYER, OPPONENT} {
+		if g.checkWin(Owner(player)) {
+			return Owner(player)
 		}
+	}
 
-		i++
-		j++
+	if !g.isGamesFull() {
+		return OPEN
 	}
 
-	// Check rows
+	won := map[Owner]int{}
 	for _, row := range g.games {
-		win = true
 		for _, game := range row {
-			if game.Eval(Player(player)) != 1 {
-				win = false
-			}
+			won[game.Owner()]++
 		}
+	}
 
+	switch {
+	case won[Owner(PLAYER)] > won[Owner(OPPONENT)]:
+		return Owner(PLAYER)
+	case won[Owner(PLAYER)] < won[Owner(OPPONENT)]:
+		return Owner(OPPONENT)
 	}
+	return DRAWN
+}
 
-	// Check columns
-	for row, r := range g.games {
-		win = true
-		for col := range r {
-			if g.games[col][row].Eval(Player(player)) != 1 {
-				win = false
-			}
+// Helper methods
+
+// checkWin checks the rows, columns and both diagonals of the macro board
+// for a line of sub-boards won by owner
+func (g *Game) checkWin(owner Owner) bool {
+	size := len(g.games)
+
+	diagonal, antiDiagonal := true, true
+	for i := 0; i < size; i++ {
+		row, col := true, true
+		for j := 0; j < size; j++ {
+			row = row && g.games[i][j].Owner() == owner
+			col = col && g.games[j][i].Owner() == owner
 		}
-		if win {
-			return win
+		if row || col {
+			return true
 		}
+
+		diagonal = diagonal && g.games[i][i].Owner() == owner
+		antiDiagonal = antiDiagonal && g.games[i][size-1-i].Owner() == owner
 	}
-	return win
+
+	return diagonal || antiDiagonal
 }
 
 func (g *Game) isGamesFull() bool {
 	for _, row := range g.games {
 		for _, game := range row {
-			if !game.IsEOG() {
+			if game.Owner() == OPEN {
 				return false
 			}
 		}
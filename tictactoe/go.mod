@@ -1,3 +1,7 @@
 module github.com/menahem/tictactoe
 
 go 1.22.3
+
+require github.com/mendel/codingames/mcts v0.0.0
+
+replace github.com/mendel/codingames/mcts => ../mcts
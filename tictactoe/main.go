@@ -1,53 +1,274 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"flag"
 	"fmt"
+	"io"
 	"os"
 	"time"
+
+	"github.com/mendel/codingames/mcts"
+)
+
+var (
+	stateKind = flag.String("state", "bitboard", "game state representation: bitboard or game")
+	workers   = flag.Int("workers", DefaultConfig.Workers, "number of goroutines searching the tree")
+	parallel  = flag.String("parallel", "tree", "parallelization of the search: tree or root")
+	rave      = flag.Float64("rave", DefaultConfig.RaveEquivalence, "RAVE equivalence parameter, 0 disables RAVE")
+	games     = flag.Int("match", 0, "play that many games of the configured search against plain UCT and exit")
+	tactical  = flag.Bool("tactical", false, "play immediate wins and blocks in rollouts")
+	transpose = flag.Int("transpositions", DefaultConfig.Transpositions, "log2 of the size of the table sharing MCTS statistics between identical positions, 0 disables it")
+	tableBits = flag.Int("solver-table", 18, "log2 of the size of the endgame solver transposition table")
+
+	genBook   = flag.String("gen-book", "", "search the openings offline, write the book source to that file and exit")
+	bookDepth = flag.Int("book-depth", 2, "number of our moves covered by the generated book")
+	bookTime  = flag.Duration("book-time", time.Second, "search time of every book position")
+
+	pondering     = flag.Bool("ponder", true, "search on the opponent's time while waiting for its move")
+	ponderWorkers = flag.Int("ponder-workers", 1, "number of goroutines searching on the opponent's time")
+
+	solveMoves   = flag.Int("solve-moves", 9, "legal moves above which the endgame solver is skipped, the default only skips the turns free to play in any sub-board")
+	solveEmpties = flag.Int("solve-empties", 24, "empty cells below which the endgame solver runs first")
 )
 
+// states builds the initial state of every representation by name
+var states = map[string]func() State{
+	"game": func() State {
+		games := make([]SubBoard, SIZE*SIZE)
+		for i := range games {
+			games[i] = NewBoard(SIZE, SIZE)
+		}
+		return NewGame(SIZE, games...)
+	},
+	"bitboard": func() State {
+		return NewBitBoard()
+	},
+}
+
 func main() {
-	const boardSize = 3
-	games := make([]State, boardSize*boardSize)
-	for i := range games {
-		games[i] = NewBoard(boardSize)
+	flag.Parse()
+
+	config := DefaultConfig
+	config.Workers = *workers
+	config.RaveEquivalence = *rave
+	config.Transpositions = *transpose
+	if *tactical {
+		config.Rollout = tacticalRollout
+	}
+	if *parallel == "root" {
+		config.Parallelism = mcts.ROOT
+	}
+
+	if *genBook != "" {
+		f, err := os.Create(*genBook)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "ERROR:", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+
+		if err := writeBook(f, generateBook(config, *bookDepth, *bookTime)); err != nil {
+			fmt.Fprintln(os.Stderr, "ERROR:", err)
+			os.Exit(1)
+		}
+		return
 	}
-	game := NewGame(boardSize, games...)
+
+	if *games > 0 {
+		match(*games, 50*time.Millisecond, config, DefaultConfig)
+		return
+	}
+
+	newState, ok := states[*stateKind]
+	if !ok {
+		fmt.Fprintln(os.Stderr, "ERROR: Unknown state representation:", *stateKind)
+		os.Exit(1)
+	}
+	game := newState()
+	config.Log = os.Stderr
+	tree := NewTree(game, config)
+	solver := NewSolver(*tableBits)
+
+	// moves played since the root of the tree, the tree descends along our
+	// move and the opponent's reply before searching again
+	var played []Action
+
+	// stopPonder ends the search running while we wait for the opponent
+	stopPonder := func() {}
+
+	in := readLines(os.Stdin)
 
 	for {
+		first, ok := <-in
+		if !ok {
+			stopPonder()
+			return
+		}
+
+		// the turn starts with the first byte of its input, pondering must
+		// stop before anything else
+		stopPonder()
+		deadline := first.at.Add(90 * time.Millisecond)
+
 		var opponentRow, opponentCol int
-		fmt.Scan(&opponentRow, &opponentCol)
-		game.Exec(OPPONENT, Move{Row: opponentRow, Col: opponentCol})
+		fmt.Sscan(first.text, &opponentRow, &opponentCol)
+
+		// -1 -1 means we play first
+		if opponentRow >= 0 && opponentCol >= 0 {
+			move := Move{Row: opponentRow, Col: opponentCol}
+			game.Exec(OPPONENT, move)
+			played = append(played, move)
+		}
 
 		var validActionCount int
-		fmt.Scan(&validActionCount)
+		fmt.Sscan((<-in).text, &validActionCount)
 
-		var validMoves []Move
+		var validMoves []Action
 		for i := 0; i < validActionCount; i++ {
 			var row, col int
-			fmt.Scan(&row, &col)
+			fmt.Sscan((<-in).text, &row, &col)
 			validMoves = append(validMoves, Move{Row: row, Col: col})
 		}
 
-		ctx, _ := context.WithTimeout(context.Background(), 90*time.Millisecond)
-
 		if len(validMoves) == 0 {
 			fmt.Fprintln(os.Stderr, "ERROR: No valid moves available")
 			continue
 		}
 
-		bestMove := MCTS(ctx, game)
-		if bestMove == nil {
+		ctx, cancel := context.WithDeadline(context.Background(), deadline)
+		tree.Advance(game, played...)
+		played = played[:0]
+
+		if actions := game.Actions(); !sameMoves(actions, validMoves) {
+			fmt.Fprintln(os.Stderr, "ERROR: Legal moves differ from the referee's, ours", actions, "referee", validMoves)
+		}
+		tree.Restrict(validMoves...)
+
+		if _, visits := tree.Root().Stats(); visits > 0 {
+			fmt.Fprintln(os.Stderr, "PONDER: reusing", visits, "visits")
+		}
+
+		bestMove, ok := Action(nil), false
+		if move, inBook := book.Lookup(game); inBook && isValidMove(validMoves, move) {
+			fmt.Fprintln(os.Stderr, "BOOK:", move)
+			bestMove, ok = move, true
+		} else if isEndgame(game, *solveMoves, *solveEmpties) {
+			// the solver gets half the time, MCTS the rest when unproven
+			solveCtx, solveCancel := context.WithTimeout(ctx, 45*time.Millisecond)
+			move, proof := solver.Solve(solveCtx, game)
+			solveCancel()
+
+			// every move of a proven loss is as bad as the next, MCTS picks
+			// the one an imperfect opponent is the most likely to get wrong
+			switch proof {
+			case mcts.WIN, mcts.DRAW:
+				fmt.Fprintln(os.Stderr, "SOLVER: proven", proof)
+				bestMove, ok = move, true
+			case mcts.LOSS:
+				fmt.Fprintln(os.Stderr, "SOLVER: proven loss, searching with MCTS")
+			}
+		}
+
+		if !ok {
+			bestMove, ok = tree.Search(ctx)
+		}
+		cancel()
+		if !ok {
 			fmt.Fprintln(os.Stderr, "ERROR: MCTS didn't calculate the moves")
 			bestMove = validMoves[0]
 		}
 
 		bm := bestMove.(Move)
+		if !isValidMove(validMoves, bm) {
+			fmt.Fprintln(os.Stderr, "ERROR: Move", bm, "is not in validMoves")
+			bm = validMoves[0].(Move)
+		}
 
 		// Output the chosen move in terms of the global board
 		fmt.Println(bm.Row, bm.Col)
 
-		game.Exec(PLAYER, Move{Row: bm.Row, Col: bm.Col})
+		game.Exec(PLAYER, bm)
+		played = append(played, bm)
+
+		if *pondering && config.Parallelism == mcts.TREE && !game.IsEOG() {
+			tree.Advance(game, played...)
+			played = played[:0]
+			stopPonder = ponder(tree, *ponderWorkers)
+		}
+	}
+}
+
+// sameMoves checks if a and b hold the same moves in any order
+func sameMoves(a, b []Action) bool {
+	if len(a) != len(b) {
+		return false
 	}
+
+	for _, action := range a {
+		if !isValidMove(b, action.(Move)) {
+			return false
+		}
+	}
+	return true
+}
+
+// line is a line of input and the time it was read at
+type line struct {
+	text string
+	at   time.Time
+}
+
+// readLines reads r in its own goroutine, so the arrival of a turn's input
+// is noticed even while pondering keeps the CPU busy. The channel is closed
+// at the end of the input.
+func readLines(r io.Reader) <-chan line {
+	lines := make(chan line, 128)
+	reader := bufio.NewReader(r)
+
+	go func() {
+		defer close(lines)
+		for {
+			text, err := reader.ReadString('\n')
+			if text != "" {
+				lines <- line{text: text, at: time.Now()}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return lines
+}
+
+// ponder searches tree with workers goroutines in the background until the
+// returned function is called, which waits for them to stop so the tree can
+// be advanced. The statistics gathered below the opponent's actual move are
+// kept.
+func ponder(tree *Tree, workers int) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		tree.Ponder(ctx, workers)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// isEndgame checks if few enough moves and empty cells are left for the
+// solver to prove the result
+func isEndgame(state State, moves, empties int) bool {
+	if len(state.Actions()) > moves {
+		return false
+	}
+
+	counter, ok := state.(Counter)
+	return ok && counter.Empties() <= empties
 }
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPonderReused checks the visits gathered on the opponent's time are
+// kept once the tree is advanced along its actual move
+func TestPonderReused(t *testing.T) {
+	state := NewBitBoard()
+	state.Exec(state.Player(), Move{Row: 4, Col: 4})
+
+	config := DefaultConfig
+	config.Workers = 1
+	tree := NewTree(state, config)
+
+	stop := ponder(tree, 1)
+	time.Sleep(50 * time.Millisecond)
+	stop()
+
+	// the opponent replies with its most pondered move
+	var reply Action
+	pondered := 0
+	for _, child := range tree.Root().Children() {
+		if _, visits := child.Stats(); visits > pondered {
+			reply, pondered = child.Action(), visits
+		}
+	}
+	if pondered == 0 {
+		t.Fatal("no visits gathered while pondering")
+	}
+
+	state.Exec(state.Player(), reply)
+	tree.Advance(state, reply)
+
+	if _, visits := tree.Root().Stats(); visits != pondered {
+		t.Errorf("advanced root has %d visits, want the %d pondered", visits, pondered)
+	}
+	if hash := tree.Root().State().(*BitBoard).Hash(); hash != state.Hash() {
+		t.Error("advanced root is not the position after the reply")
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// match plays games between two search configurations on the bitboard,
+// alternating who starts, and prints the wins, draws and losses of a
+func match(games int, moveTime time.Duration, a, b Config) {
+	var wins, draws, losses int
+
+	for i := 0; i < games; i++ {
+		// a plays first in even games
+		configs := map[Player]Config{PLAYER: a, OPPONENT: b}
+		if i%2 == 1 {
+			configs = map[Player]Config{PLAYER: b, OPPONENT: a}
+		}
+
+		state := NewBitBoard()
+		for !state.IsEOG() {
+			ctx, cancel := context.WithTimeout(context.Background(), moveTime)
+			action, _ := NewTree(state, configs[state.Player()]).Search(ctx)
+			cancel()
+
+			state.Exec(state.Player(), action)
+		}
+
+		aPlayer := PLAYER
+		if i%2 == 1 {
+			aPlayer = OPPONENT
+		}
+
+		switch state.Winner() {
+		case Owner(aPlayer):
+			wins++
+		case DRAWN:
+			draws++
+		default:
+			losses++
+		}
+	}
+
+	fmt.Printf("wins %d, draws %d, losses %d\n", wins, draws, losses)
+}
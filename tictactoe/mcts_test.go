@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mendel/codingames/mcts"
+)
+
+// position plays moves alternately on an empty 3×3 board, PLAYER first
+func position(moves ...Move) *Board {
+	board := NewBoard(3, 3)
+	for _, move := range moves {
+		board.Exec(board.Player(), move)
+	}
+	return board
+}
+
+// search runs a single worker search of board for d
+func search(board *Board, d time.Duration) (*Tree, Action, bool) {
+	config := DefaultConfig
+	config.Workers = 1
+	tree := NewTree(board, config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+	action, ok := tree.Search(ctx)
+
+	return tree, action, ok
+}
+
+// sign returns the value of a root child from the perspective of the player
+// to move at the root: its proof once solved, the sign of its wins otherwise
+func sign(child *mcts.Node[State, Action]) int {
+	switch child.Proof() {
+	case mcts.WIN:
+		return 1
+	case mcts.LOSS:
+		return -1
+	case mcts.DRAW:
+		return 0
+	}
+
+	switch wins, _ := child.Stats(); {
+	case wins > 0:
+		return 1
+	case wins < 0:
+		return -1
+	}
+	return 0
+}
+
+func TestSearchTinyPositions(t *testing.T) {
+	tests := []struct {
+		name  string
+		board *Board
+		want  Move
+		// signs of the root children that must be settled by the search
+		signs map[Move]int
+	}{
+		{
+			// X X .
+			// O O .
+			// . . .
+			name:  "immediate win",
+			board: position(Move{0, 0}, Move{1, 0}, Move{0, 1}, Move{1, 1}),
+			want:  Move{0, 2},
+			signs: map[Move]int{{0, 2}: 1},
+		},
+		{
+			// O O .
+			// . X .
+			// . X .
+			name:  "forced block",
+			board: position(Move{1, 1}, Move{0, 0}, Move{2, 1}, Move{0, 1}),
+			want:  Move{0, 2},
+			signs: map[Move]int{{0, 2}: 0, {1, 0}: -1, {1, 2}: -1, {2, 0}: -1, {2, 2}: -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, action, ok := search(tt.board, 200*time.Millisecond)
+			if !ok || action != tt.want {
+				t.Fatalf("got move %v, want %v", action, tt.want)
+			}
+
+			for _, child := range tree.Root().Children() {
+				want, ok := tt.signs[child.Action().(Move)]
+				if !ok {
+					continue
+				}
+
+				if got := sign(child); got != want {
+					wins, visits := child.Stats()
+					t.Errorf("child %v has sign %d, want %d (proof %v, wins %.1f over %d visits)", child.Action(), got, want, child.Proof(), wins, visits)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchEmptyBoardProvesNoOpening(t *testing.T) {
+	tree, _, ok := search(position(), 200*time.Millisecond)
+	if !ok {
+		t.Fatal("no move found on the empty board")
+	}
+
+	// every opening draws, none may be proven a win or a loss
+	for _, child := range tree.Root().Children() {
+		if proof := child.Proof(); proof == mcts.WIN || proof == mcts.LOSS {
+			t.Errorf("opening %v proven %v", child.Action(), proof)
+		}
+	}
+}
+
+func TestSolverEmptyBoardDraws(t *testing.T) {
+	_, proof := NewSolver(16).Solve(context.Background(), position())
+	if proof != mcts.DRAW {
+		t.Errorf("got proof %v, want %v", proof, mcts.DRAW)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/mendel/codingames/mcts"
+)
+
+// TestParallelSearchStress plays the opening of a game with many workers in
+// every parallel configuration, advancing and restricting the tree between
+// searches and pondering in between like main does. It is meant to be run
+// with -race.
+func TestParallelSearchStress(t *testing.T) {
+	parallelisms := map[string]mcts.Parallelism{"tree": mcts.TREE, "root": mcts.ROOT}
+
+	for kind, parallelism := range parallelisms {
+		for _, transpositions := range []int{0, 12} {
+			for _, rave := range []float64{0, 300} {
+				name := fmt.Sprintf("%s/transpositions=%d/rave=%v", kind, transpositions, rave)
+				t.Run(name, func(t *testing.T) {
+					config := DefaultConfig
+					config.Workers = 16
+					config.Parallelism = parallelism
+					config.Transpositions = transpositions
+					config.RaveEquivalence = rave
+
+					stress(t, config, 12)
+				})
+			}
+		}
+	}
+}
+
+// stress plays plies moves of a game with a single tree searching for both
+// players, allowing only the first half of the legal moves every turn
+func stress(t *testing.T, config Config, plies int) {
+	state := NewBitBoard()
+	tree := NewTree(state, config)
+
+	var played []Action
+	for ply := 0; ply < plies && !state.IsEOG(); ply++ {
+		tree.Advance(state, played...)
+		played = played[:0]
+
+		legal := state.Actions()
+		allowed := legal[:(len(legal)+1)/2]
+		tree.Restrict(allowed...)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		action, ok := tree.Search(ctx)
+		cancel()
+
+		if !ok {
+			t.Fatalf("ply %d: no move found", ply)
+		}
+		if !isValidMove(allowed, action.(Move)) {
+			t.Fatalf("ply %d: move %v outside of the restricted %v", ply, action, allowed)
+		}
+
+		state.Exec(state.Player(), action)
+		played = append(played, action)
+
+		stop := ponder(tree, 4)
+		time.Sleep(2 * time.Millisecond)
+		stop()
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"math/rand"
+
+	"github.com/mendel/codingames/mcts"
+)
+
+// randomRollout plays uniformly at random
+var randomRollout = mcts.Random[State, Action]
+
+// tacticalRollout plays immediate wins, blocks immediate losses and avoids
+// sending the opponent to a board where it wins the game, otherwise it plays
+// at random. Only the bitboard knows its sub-boards, any other state plays
+// at random.
+func tacticalRollout(state State, actions []Action) Action {
+	b, ok := state.(*BitBoard)
+	if !ok {
+		return randomRollout(state, actions)
+	}
+
+	return b.tactical(actions)
+}
+
+func (b *BitBoard) tactical(actions []Action) Action {
+	me, opp := idx(b.player), idx(3-b.player)
+
+	var win, block Action
+	safe := make([]Action, 0, len(actions))
+	for _, action := range actions {
+		sub, cell := fromMove(action.(Move))
+
+		if wins[b.cells[me][sub]|1<<cell] {
+			if wins[b.macro[me]|1<<sub] {
+				return action
+			}
+			win = action
+		} else if wins[b.cells[opp][sub]|1<<cell] {
+			block = action
+		}
+
+		next := *b
+		next.Exec(b.player, action)
+		if !next.canWin(3 - b.player) {
+			safe = append(safe, action)
+		}
+	}
+
+	switch {
+	case win != nil:
+		return win
+	case block != nil:
+		return block
+	case len(safe) > 0:
+		return safe[rand.Intn(len(safe))]
+	}
+	return actions[rand.Intn(len(actions))]
+}
+
+// canWin checks if p wins the macro board with its next move
+func (b *BitBoard) canWin(p Player) bool {
+	for sub := 0; sub < 9; sub++ {
+		if b.closed()&(1<<sub) != 0 || (b.target >= 0 && int(b.target) != sub) {
+			continue
+		}
+		if !wins[b.macro[idx(p)]|1<<sub] {
+			continue
+		}
+
+		free := FULL &^ (b.cells[0][sub] | b.cells[1][sub])
+		for ; free != 0; free &= free - 1 {
+			if wins[b.cells[idx(p)][sub]|free&-free] {
+				return true
+			}
+		}
+	}
+	return false
+}
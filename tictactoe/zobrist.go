@@ -0,0 +1,21 @@
+package main
+
+// Zobrist features of a position besides the cells, which are keyed by the
+// player owning them
+const (
+	zobristPlayer = 3 + iota
+	zobristTarget
+)
+
+// zobrist returns the random key of a feature of a position: kind tells the
+// features apart and index is their value, like a cell number. The keys are
+// derived with splitmix64 instead of stored in a table, so boards of any size
+// get them. A position hashes to the XOR of the keys of its features, which
+// lets Exec update the hash with the few features it changes.
+func zobrist(kind, index int) uint64 {
+	x := uint64(kind)<<32 | uint64(uint32(index))
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+	x = (x ^ x>>27) * 0x94d049bb133111eb
+	return x ^ x>>31
+}